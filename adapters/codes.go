@@ -0,0 +1,198 @@
+package adapters
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrorCode is a machine-readable category of evaluation errors.
+type ErrorCode string
+
+// Coded is implemented by errors with an error code.
+type Coded interface {
+	Code() ErrorCode
+}
+
+const (
+	// CodeUnknown is the code of errors without a category.
+	CodeUnknown ErrorCode = "unknown"
+	// CodeInvalidArguments is the code of nodes built with missing or invalid arguments.
+	CodeInvalidArguments ErrorCode = "invalidArguments"
+	// CodeTypeMismatch is the code of values with unexpected or incompatible types.
+	CodeTypeMismatch ErrorCode = "typeMismatch"
+	// CodeDefinitionNotFound is the code of references to undefined keys.
+	CodeDefinitionNotFound ErrorCode = "definitionNotFound"
+	// CodeDefinitionNotCallable is the code of calls to definitions that are not functions.
+	CodeDefinitionNotCallable ErrorCode = "definitionNotCallable"
+	// CodeInvalidDefinitionKey is the code of definitions with malformed keys.
+	CodeInvalidDefinitionKey ErrorCode = "invalidDefinitionKey"
+	// CodeDefinitionProvider is the code of definition provider failures.
+	CodeDefinitionProvider ErrorCode = "definitionProvider"
+	// CodeCallFailure is the code of called functions that failed.
+	CodeCallFailure ErrorCode = "callFailure"
+	// CodeCustom is the code of errors returned by the error node.
+	CodeCustom ErrorCode = "custom"
+)
+
+// ErrorCodes are the known error codes, excluding CodeUnknown.
+var ErrorCodes = []ErrorCode{
+	CodeInvalidArguments,
+	CodeTypeMismatch,
+	CodeDefinitionNotFound,
+	CodeDefinitionNotCallable,
+	CodeInvalidDefinitionKey,
+	CodeDefinitionProvider,
+	CodeCallFailure,
+	CodeCustom,
+}
+
+// IsValid reports whether the code is one of ErrorCodes.
+func (c ErrorCode) IsValid() bool {
+	return slices.Contains(ErrorCodes, c)
+}
+
+// CodeOf returns the code of the first coded error in the tree, or CodeUnknown.
+// Like errors.As, joined errors are searched in order.
+func CodeOf(err error) ErrorCode {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+
+	return CodeUnknown
+}
+
+// HasCode reports whether any error in the tree has the code, including joined errors.
+// Unlike CodeOf, it also matches causes of other coded errors,
+// like a definitionNotFound error returned by a function, causing a callFailure.
+func HasCode(err error, code ErrorCode) bool {
+	if coded, ok := err.(Coded); ok && coded.Code() == code {
+		return true
+	}
+
+	switch err := err.(type) {
+	case interface{ Unwrap() error }:
+		return HasCode(err.Unwrap(), code)
+	case interface{ Unwrap() []error }:
+		return slices.ContainsFunc(err.Unwrap(), func(err error) bool {
+			return HasCode(err, code)
+		})
+	default:
+		return false
+	}
+}
+
+func (e StringError) Code() ErrorCode {
+	if e == ErrAllNodesMustMatch || e == ErrNullOperand {
+		return CodeTypeMismatch
+	}
+
+	return CodeInvalidArguments
+}
+
+func (e IncompatiblePairError) Code() ErrorCode {
+	return CodeTypeMismatch
+}
+
+func (e TypeConversionError) Code() ErrorCode {
+	return CodeTypeMismatch
+}
+
+func (e UnexpectedTypeError) Code() ErrorCode {
+	return CodeTypeMismatch
+}
+
+func (e DefinitionNotFoundError) Code() ErrorCode {
+	return CodeDefinitionNotFound
+}
+
+func (e DefinitionNotCallableError) Code() ErrorCode {
+	return CodeDefinitionNotCallable
+}
+
+func (e InvalidDefinitionKey) Code() ErrorCode {
+	return CodeInvalidDefinitionKey
+}
+
+func (e DefinitionProviderError) Code() ErrorCode {
+	return CodeDefinitionProvider
+}
+
+func (e CallError) Code() ErrorCode {
+	return CodeCallFailure
+}
+
+func (e CustomError) Code() ErrorCode {
+	return CodeCustom
+}
+
+// AsNodeError finds the first NodeError in the chain.
+func AsNodeError(err error) (NodeError, bool) {
+	return as[NodeError](err)
+}
+
+// AsIncompatiblePairError finds the first IncompatiblePairError in the chain.
+func AsIncompatiblePairError(err error) (IncompatiblePairError, bool) {
+	return as[IncompatiblePairError](err)
+}
+
+// AsTypeConversionError finds the first TypeConversionError in the chain.
+func AsTypeConversionError(err error) (TypeConversionError, bool) {
+	return as[TypeConversionError](err)
+}
+
+// AsUnexpectedTypeError finds the first UnexpectedTypeError in the chain.
+func AsUnexpectedTypeError(err error) (UnexpectedTypeError, bool) {
+	return as[UnexpectedTypeError](err)
+}
+
+// AsDefinitionNotFoundError finds the first DefinitionNotFoundError in the chain.
+func AsDefinitionNotFoundError(err error) (DefinitionNotFoundError, bool) {
+	return as[DefinitionNotFoundError](err)
+}
+
+// AsDefinitionNotCallableError finds the first DefinitionNotCallableError in the chain.
+func AsDefinitionNotCallableError(err error) (DefinitionNotCallableError, bool) {
+	return as[DefinitionNotCallableError](err)
+}
+
+// AsInvalidDefinitionKey finds the first InvalidDefinitionKey in the chain.
+func AsInvalidDefinitionKey(err error) (InvalidDefinitionKey, bool) {
+	return as[InvalidDefinitionKey](err)
+}
+
+// AsDefinitionProviderError finds the first DefinitionProviderError in the chain.
+func AsDefinitionProviderError(err error) (DefinitionProviderError, bool) {
+	return as[DefinitionProviderError](err)
+}
+
+// AsCallError finds the first CallError in the chain.
+func AsCallError(err error) (CallError, bool) {
+	return as[CallError](err)
+}
+
+// AsCustomError finds the first CustomError in the chain.
+func AsCustomError(err error) (CustomError, bool) {
+	return as[CustomError](err)
+}
+
+func as[T error](err error) (T, bool) {
+	var target T
+	ok := errors.As(err, &target)
+
+	return target, ok
+}
+
+var (
+	_ Coded = NodeError{}
+	_ Coded = StringError("")
+	_ Coded = IncompatiblePairError{}
+	_ Coded = TypeConversionError{}
+	_ Coded = UnexpectedTypeError{}
+	_ Coded = DefinitionNotFoundError{}
+	_ Coded = DefinitionNotCallableError{}
+	_ Coded = InvalidDefinitionKey{}
+	_ Coded = DefinitionProviderError{}
+	_ Coded = CallError{}
+	_ Coded = CustomError{}
+)
@@ -1,16 +1,24 @@
 package adapters
 
 import (
-	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 type (
 	StringError string
 
+	// NodeError is returned when a node fails to evaluate.
+	// Nested node errors form a chain, from the root node to the node where the failure originated.
 	NodeError struct {
 		NodeScalar string
 		Cause      error
+		// Node is the node that failed, used for resolving the path of nested errors.
+		Node Named
+		// Span is the source span of the origin node, set by SpanMap.Locate for nodes decoded from text.
+		Span *Span
 	}
 
 	DefinitionNotFoundError struct {
@@ -25,11 +33,39 @@ type (
 		DefinitionKey string
 	}
 
+	DefinitionProviderError struct {
+		DefinitionKey string
+		Cause         error
+	}
+
 	IncompatiblePairError struct {
 		First  any
 		Second any
 	}
 
+	// TypeConversionError is returned when a value cannot be converted to the target type.
+	TypeConversionError struct {
+		Value  any
+		Target reflect.Type
+	}
+
+	// UnexpectedTypeError is returned when a node evaluates to a type that cannot be used by its parent.
+	UnexpectedTypeError struct {
+		Expected string
+		Got      any
+	}
+
+	// CallError is returned when a called function fails, or cannot be called with the given arguments.
+	CallError struct {
+		FuncName string
+		Cause    error
+	}
+
+	// CustomError is returned by the error node, for rules that deliberately fail.
+	CustomError struct {
+		Message string
+	}
+
 	DefinitionError interface {
 		Key() string
 	}
@@ -39,12 +75,21 @@ const (
 	ErrAllNodesMustMatch StringError = "all nodes must be of the same type"
 	ErrAllNodesMustBeSet StringError = "all nodes must be set"
 	ErrMustHaveArguments StringError = "must have at least one argument"
+	ErrDivisionByZero    StringError = "division by zero"
+	ErrNullOperand       StringError = "operand cannot be null"
+	ErrNumberOverflow    StringError = "number overflow"
+	ErrNegativeRoot      StringError = "square root of a negative number"
+	ErrInvalidRange      StringError = "min cannot be greater than max"
+	ErrInvalidPlaces     StringError = "places out of range"
+	ErrEmptyCollection   StringError = "collection cannot be empty"
+	ErrInvalidPercentile StringError = "percentile must be between 0 and 100"
 )
 
 func NewNodeError(namedNode Named, err error) NodeError {
 	return NodeError{
 		NodeScalar: namedNode.Scalar(),
 		Cause:      err,
+		Node:       namedNode,
 	}
 }
 
@@ -61,11 +106,50 @@ func (e NodeError) Scalar() string {
 }
 
 func (e NodeError) Error() string {
-	if errors.As(e.Cause, &NodeError{}) {
-		return fmt.Sprintf("%s.%s", e.NodeScalar, e.Cause.Error())
+	return fmt.Sprintf("%s: %s", e.Path(), e.Origin().Cause)
+}
+
+// Origin returns the innermost node error of the chain, where the failure originated.
+func (e NodeError) Origin() NodeError {
+	for {
+		inner, ok := e.Cause.(NodeError)
+		if !ok {
+			return e
+		}
+
+		e = inner
 	}
+}
+
+// Path returns the shape path from the node to the origin of the error.
+// Named arguments are selected by key, and the others by index. Example: if.condition.gte, or[1].equal.
+// Arguments evaluated from references or literals end the path with their selector.
+func (e NodeError) Path() string {
+	var path strings.Builder
+
+	path.WriteString(e.NodeScalar)
+
+	for {
+		inner, ok := e.Cause.(NodeError)
+		if !ok {
+			return path.String()
+		}
+
+		selector, found := argSelector(e.Node, inner.Node)
+		path.WriteString(selector)
+
+		if !found || isExpression(inner.Node) {
+			path.WriteString(".")
+			path.WriteString(inner.NodeScalar)
+		}
+
+		e = inner
+	}
+}
 
-	return fmt.Sprintf("%s: %s", e.NodeScalar, e.Cause.Error())
+// Code returns the code of the cause.
+func (e NodeError) Code() ErrorCode {
+	return CodeOf(e.Cause)
 }
 
 func (e NodeError) Unwrap() error {
@@ -88,10 +172,38 @@ func (e IncompatiblePairError) Error() string {
 	return fmt.Sprintf("types %T and %T are not compatible", e.First, e.Second)
 }
 
+func (e TypeConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %v (%T) to %s", e.Value, e.Value, e.Target)
+}
+
+func (e UnexpectedTypeError) Error() string {
+	return fmt.Sprintf("expected %s got %T", e.Expected, e.Got)
+}
+
+func (e CallError) Error() string {
+	return fmt.Sprintf("calling '%s': %s", e.FuncName, e.Cause)
+}
+
+func (e CallError) Unwrap() error {
+	return e.Cause
+}
+
+func (e CustomError) Error() string {
+	return e.Message
+}
+
 func (e InvalidDefinitionKey) Error() string {
 	return fmt.Sprintf("definition key '%s' is invalid", e.DefinitionKey)
 }
 
+func (e DefinitionProviderError) Error() string {
+	return fmt.Sprintf("providing definition '%s': %s", e.DefinitionKey, e.Cause)
+}
+
+func (e DefinitionProviderError) Unwrap() error {
+	return e.Cause
+}
+
 func (e DefinitionNotCallableError) Key() string {
 	return e.DefinitionKey
 }
@@ -104,10 +216,53 @@ func (e InvalidDefinitionKey) Key() string {
 	return e.DefinitionKey
 }
 
+func (e DefinitionProviderError) Key() string {
+	return e.DefinitionKey
+}
+
 var (
 	_ Node            = NodeError{}
 	_ Value           = NodeError{}
 	_ DefinitionError = DefinitionNotCallableError{}
 	_ DefinitionError = DefinitionNotFoundError{}
 	_ DefinitionError = InvalidDefinitionKey{}
+	_ DefinitionError = DefinitionProviderError{}
 )
+
+// argSelector returns the selector of the argument of node evaluated by arg.
+func argSelector(node Named, arg Named) (string, bool) {
+	shaped, ok := node.(Shaped)
+	if !ok || !hasIdentity(arg) {
+		return "", false
+	}
+
+	for i, keyNode := range shaped.Shape() {
+		selector := "[" + strconv.Itoa(i) + "]"
+		if keyNode.Key != "" {
+			selector = "." + keyNode.Key
+		}
+
+		switch {
+		case sameNode(keyNode.Case, arg):
+			return selector + ".case", true
+		case sameNode(keyNode.Node, arg):
+			return selector, true
+		}
+	}
+
+	return "", false
+}
+
+func isExpression(node Named) bool {
+	typed, ok := node.(Typed)
+	return !ok || typed.Type() == NodeTypeExpression
+}
+
+// hasIdentity reports whether the node is a pointer, which can be compared and used as map key.
+func hasIdentity(node any) bool {
+	return node != nil && reflect.TypeOf(node).Kind() == reflect.Pointer
+}
+
+func sameNode(a, b any) bool {
+	return hasIdentity(a) && hasIdentity(b) && a == b
+}
@@ -0,0 +1,162 @@
+package adapters_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodeError(t *testing.T) {
+	scope, err := gon.NewScope().WithValues(gon.Values{
+		"age":  gon.Literal(int64(18)),
+		"fail": gon.Literal(func() error { return adapters.DefinitionNotFoundError{DefinitionKey: "inner"} }),
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		input   string
+		message string
+		code    adapters.ErrorCode
+		span    adapters.Span
+	}{
+		{
+			name:    "should select named arguments by key",
+			input:   `if(gte(age, "18"), 1)`,
+			message: "if.condition.gte: types int64 and string are not compatible",
+			code:    adapters.CodeTypeMismatch,
+			span:    adapters.Span{Start: 3, End: 17, Line: 1, Column: 4},
+		},
+		{
+			name:    "should select unnamed arguments by index",
+			input:   `or(false, not(1))`,
+			message: "or[1].not: expected bool got int64",
+			code:    adapters.CodeTypeMismatch,
+			span:    adapters.Span{Start: 10, End: 16, Line: 1, Column: 11},
+		},
+		{
+			name:    "should end with the selector of references",
+			input:   "not(\n\tmissing,\n)",
+			message: "not.expression: definition 'missing' not found",
+			code:    adapters.CodeDefinitionNotFound,
+			span:    adapters.Span{Start: 6, End: 13, Line: 2, Column: 2},
+		},
+		{
+			name:    "should select cases",
+			input:   `switch(1, "1": true, default: false)`,
+			message: "switch: types int64 and string are not compatible",
+			code:    adapters.CodeTypeMismatch,
+			span:    adapters.Span{Start: 0, End: 36, Line: 1, Column: 1},
+		},
+		{
+			name:    "should select case expressions",
+			input:   `cond(not(1): true)`,
+			message: "cond[0].case.not: expected bool got int64",
+			code:    adapters.CodeTypeMismatch,
+			span:    adapters.Span{Start: 5, End: 11, Line: 1, Column: 6},
+		},
+		{
+			name:    "should use the outermost code",
+			input:   `call("fail")`,
+			message: "call: calling 'fail': definition 'inner' not found",
+			code:    adapters.CodeCallFailure,
+			span:    adapters.Span{Start: 0, End: 12, Line: 1, Column: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node, spans, err := encoding.DecodeWithSpans([]byte(tc.input), encoding.DefaultExpressionCodex)
+			require.NoError(t, err)
+
+			_, err = scope.Compute(node)
+			require.EqualError(t, err, tc.message)
+			require.Equal(t, tc.code, adapters.CodeOf(err))
+
+			nodeErr, ok := adapters.AsNodeError(spans.Locate(err))
+			require.True(t, ok)
+			require.NotNil(t, nodeErr.Span)
+			require.Equal(t, tc.span, *nodeErr.Span)
+		})
+	}
+
+	t.Run("should fall back to scalars of nodes outside the shape", func(t *testing.T) {
+		err := adapters.NodeError{
+			NodeScalar: "outer",
+			Cause:      adapters.NodeError{NodeScalar: "inner", Cause: adapters.ErrMustHaveArguments},
+		}
+
+		require.EqualError(t, err, "outer.inner: must have at least one argument")
+		require.Equal(t, "inner", err.Origin().NodeScalar)
+		require.Equal(t, adapters.CodeInvalidArguments, err.Code())
+	})
+
+	t.Run("should not locate nodes built from code", func(t *testing.T) {
+		_, err := scope.Compute(gon.Not(gon.Literal(1)))
+
+		nodeErr, ok := adapters.AsNodeError(adapters.SpanMap{}.Locate(err))
+		require.True(t, ok)
+		require.Nil(t, nodeErr.Span)
+	})
+
+	t.Run("should not locate other errors", func(t *testing.T) {
+		require.Equal(t, adapters.ErrMustHaveArguments, adapters.SpanMap{}.Locate(adapters.ErrMustHaveArguments))
+	})
+}
+
+func Test_ErrorCodes(t *testing.T) {
+	t.Run("should return unknown for uncoded errors", func(t *testing.T) {
+		require.Equal(t, adapters.CodeUnknown, adapters.CodeOf(errors.New("plain")))
+		require.Equal(t, adapters.CodeUnknown, adapters.CodeOf(nil))
+	})
+
+	t.Run("should match codes of causes", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", adapters.CallError{
+			FuncName: "f",
+			Cause:    adapters.DefinitionNotFoundError{DefinitionKey: "x"},
+		})
+
+		require.Equal(t, adapters.CodeCallFailure, adapters.CodeOf(err))
+		require.True(t, adapters.HasCode(err, adapters.CodeCallFailure))
+		require.True(t, adapters.HasCode(err, adapters.CodeDefinitionNotFound))
+		require.False(t, adapters.HasCode(err, adapters.CodeCustom))
+	})
+
+	t.Run("should match codes of joined errors", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", errors.Join(
+			errors.New("plain"),
+			adapters.CallError{FuncName: "f", Cause: adapters.CustomError{Message: "failed"}},
+		))
+
+		require.Equal(t, adapters.CodeCallFailure, adapters.CodeOf(err))
+		require.True(t, adapters.HasCode(err, adapters.CodeCallFailure))
+		require.True(t, adapters.HasCode(err, adapters.CodeCustom))
+		require.False(t, adapters.HasCode(err, adapters.CodeTypeMismatch))
+		require.False(t, adapters.HasCode(nil, adapters.CodeCustom))
+	})
+
+	t.Run("should validate codes", func(t *testing.T) {
+		for _, code := range adapters.ErrorCodes {
+			require.True(t, code.IsValid(), code)
+		}
+
+		require.False(t, adapters.CodeUnknown.IsValid())
+		require.False(t, adapters.ErrorCode("other").IsValid())
+	})
+
+	t.Run("should find errors by category", func(t *testing.T) {
+		err := adapters.NewNodeError(gon.Literal(1), adapters.CustomError{Message: "failed"})
+
+		custom, ok := adapters.AsCustomError(err)
+		require.True(t, ok)
+		require.Equal(t, "failed", custom.Message)
+
+		_, ok = adapters.AsCallError(err)
+		require.False(t, ok)
+	})
+}
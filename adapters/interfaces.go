@@ -47,6 +47,25 @@ type (
 		DefinitionWriter
 	}
 
+	// DefinitionProvider resolves definitions lazily from an external source,
+	// like a feature-flag store, a database row or the environment.
+	// It should return false if it doesn't provide the key, so the next provider can be consulted.
+	// Providers must be safe for concurrent use.
+	DefinitionProvider interface {
+		Provide(ctx context.Context, key string) (Value, bool, error)
+	}
+
+	// DefinitionPrefetcher is an optional interface for a DefinitionProvider.
+	// It allows the provider to load all keys an expression depends on in a single round-trip.
+	DefinitionPrefetcher interface {
+		Prefetch(ctx context.Context, keys []string) error
+	}
+
+	// FunctionReader is an optional interface for a Scope, resolving the functions registered with it.
+	FunctionReader interface {
+		Function(name string) (Callable, bool)
+	}
+
 	// Scope defines a block capable of evaluating expressions.
 	// It should be able to act as a context, as well as resolve definitions.
 	Scope interface {
@@ -64,8 +83,10 @@ type (
 	}
 
 	// KeyNode defines a key-node pair, used for named parameters.
+	// Case is set instead of Key for case arguments, like in switch and cond, where the key is an expression.
 	KeyNode struct {
 		Key  string
+		Case Node
 		Node Node
 	}
 
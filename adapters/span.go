@@ -0,0 +1,43 @@
+package adapters
+
+import "fmt"
+
+type (
+	// Span is the source range of a node decoded from text.
+	Span struct {
+		// Start and End are the byte offsets of the node, End is exclusive.
+		Start, End int
+		// Line and Column are the position of Start, starting at 1. Columns are counted in runes.
+		Line, Column int
+	}
+
+	// SpanMap maps decoded nodes to their source spans.
+	SpanMap map[Node]Span
+)
+
+func (s Span) String() string {
+	return fmt.Sprintf("%d:%d", s.Line, s.Column)
+}
+
+// Locate returns the node error with the span of its origin.
+// If the origin has no span, like nodes created by constructors, the span of its closest parent is used.
+// Other errors are returned unchanged.
+func (m SpanMap) Locate(err error) error {
+	nodeErr, ok := err.(NodeError)
+	if !ok {
+		return err
+	}
+
+	for e, ok := nodeErr, true; ok; e, ok = e.Cause.(NodeError) {
+		node, isNode := e.Node.(Node)
+		if !isNode || !hasIdentity(node) {
+			continue
+		}
+
+		if span, found := m[node]; found {
+			nodeErr.Span = &span
+		}
+	}
+
+	return nodeErr
+}
@@ -2,6 +2,10 @@ package adapters
 
 type NodeType uint8
 
+// KeywordArgs are the evaluated keyword arguments of a call.
+// They are given to Callable.Call as the last argument, when the call has any.
+type KeywordArgs map[string]any
+
 const (
 	NodeTypeInvalid NodeType = iota
 	// NodeTypeExpression represents an expression() node type. Example: if()
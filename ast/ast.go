@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/sonalys/gon/adapters"
 )
@@ -12,6 +13,7 @@ type (
 
 	KeyNode struct {
 		Key  string
+		Case AstNode
 		Node AstNode
 	}
 
@@ -20,20 +22,45 @@ type (
 	}
 
 	Expression struct {
-		Scalar  string
-		KeyArgs []KeyNode
+		Scalar   string
+		KeyArgs  []KeyNode
+		Comments Comments
 	}
 
 	Literal struct {
-		Value any
+		Value    any
+		Comments Comments
 	}
 
 	Reference struct {
-		Name string
+		Name     string
+		Comments Comments
 	}
+
+	// Comments are the source comments attached to a node, including their '//' or '/* */' markers.
+	// Leading comments are written before the node, and trailing comments after it.
+	Comments struct {
+		Leading  []string
+		Trailing []string
+	}
+
+	// CommentMap attaches comments to the nodes of a decoded tree.
+	// Nodes are matched by identity, so only pointer nodes can have comments.
+	CommentMap map[adapters.Node]Comments
 )
 
+// IsEmpty reports whether there are no comments.
+func (c Comments) IsEmpty() bool {
+	return len(c.Leading) == 0 && len(c.Trailing) == 0
+}
+
+// Parse converts the node tree into an ast.
 func Parse(rootExpression adapters.Node) (AstNode, error) {
+	return ParseWithComments(rootExpression, nil)
+}
+
+// ParseWithComments converts the node tree into an ast, attaching the comments of each node.
+func ParseWithComments(rootExpression adapters.Node, comments CommentMap) (AstNode, error) {
 	nodeExpression, ok := rootExpression.(adapters.SerializableNode)
 	if !ok {
 		return nil, fmt.Errorf("parsing node to ast: %T", rootExpression)
@@ -47,25 +74,36 @@ func Parse(rootExpression adapters.Node) (AstNode, error) {
 		keyArgs := make([]KeyNode, 0, len(keyExpressions))
 
 		for i := range keyExpressions {
-			parsed, err := Parse(keyExpressions[i].Node)
+			parsed, err := ParseWithComments(keyExpressions[i].Node, comments)
 			if err != nil {
 				return nil, fmt.Errorf("parsing keyed expression: %w", err)
 			}
 
+			var parsedCase AstNode
+			if keyExpressions[i].Case != nil {
+				parsedCase, err = ParseWithComments(keyExpressions[i].Case, comments)
+				if err != nil {
+					return nil, fmt.Errorf("parsing case expression: %w", err)
+				}
+			}
+
 			keyArgs = append(keyArgs, KeyNode{
 				Key:  keyExpressions[i].Key,
+				Case: parsedCase,
 				Node: parsed,
 			})
 		}
 
 		return Expression{
-			Scalar:  name,
-			KeyArgs: keyArgs,
+			Scalar:   name,
+			KeyArgs:  keyArgs,
+			Comments: comments.lookup(rootExpression),
 		}, nil
 	case adapters.NodeTypeReference:
 		name := nodeExpression.Scalar()
 		return Reference{
-			Name: name,
+			Name:     name,
+			Comments: comments.lookup(rootExpression),
 		}, nil
 	case adapters.NodeTypeLiteral:
 		valuer, ok := rootExpression.(adapters.Valued)
@@ -76,7 +114,8 @@ func Parse(rootExpression adapters.Node) (AstNode, error) {
 		}
 
 		return Literal{
-			Value: valuer.Value(),
+			Value:    valuer.Value(),
+			Comments: comments.lookup(rootExpression),
 		}, nil
 	default:
 		return Invalid{
@@ -84,3 +123,17 @@ func Parse(rootExpression adapters.Node) (AstNode, error) {
 		}, nil
 	}
 }
+
+// lookup returns the comments attached to the node.
+func (m CommentMap) lookup(node adapters.Node) Comments {
+	if len(m) == 0 || !HasIdentity(node) {
+		return Comments{}
+	}
+
+	return m[node]
+}
+
+// HasIdentity reports whether the node is a pointer, so it can be used as a CommentMap key.
+func HasIdentity(node adapters.Node) bool {
+	return node != nil && reflect.TypeOf(node).Kind() == reflect.Pointer
+}
@@ -73,3 +73,41 @@ func Test_Parse(t *testing.T) {
 		require.True(t, ok)
 	})
 }
+
+func Test_ParseWithComments(t *testing.T) {
+	t.Run("should attach comments to nodes", func(t *testing.T) {
+		condition := gon.Literal(true)
+		reference := gon.Reference("key")
+		rootNode := gon.If(condition, reference)
+
+		comments := ast.CommentMap{
+			rootNode:  {Leading: []string{"// root"}},
+			condition: {Trailing: []string{"/* condition */"}},
+		}
+
+		astNode, err := ast.ParseWithComments(rootNode, comments)
+		require.NoError(t, err)
+
+		expected := ast.Expression{
+			Scalar: "if",
+			KeyArgs: []ast.KeyNode{
+				{
+					Key: "condition",
+					Node: ast.Literal{
+						Value:    true,
+						Comments: ast.Comments{Trailing: []string{"/* condition */"}},
+					},
+				},
+				{
+					Key: "then",
+					Node: ast.Reference{
+						Name: "key",
+					},
+				},
+			},
+			Comments: ast.Comments{Leading: []string{"// root"}},
+		}
+
+		assert.Equal(t, expected, astNode)
+	})
+}
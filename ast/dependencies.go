@@ -0,0 +1,301 @@
+package ast
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+type (
+	// DependencyKind defines how an expression depends on a definition.
+	DependencyKind uint8
+
+	// Dependency represents a definition required for evaluating an expression.
+	Dependency struct {
+		Name string
+		Kind DependencyKind
+		// Optional is true when the expression guards the definition with exists, coalesce or try,
+		// meaning the expression can be evaluated even if the definition is missing.
+		Optional bool
+	}
+)
+
+const (
+	DependencyKindInvalid DependencyKind = iota
+	// DependencyKindReference represents a referenced definition. Example: person.age.
+	DependencyKindReference
+	// DependencyKindFunction represents a called definition. Example: call("greet").
+	DependencyKindFunction
+	// DependencyKindKey represents a definition key checked by exists or coalesce. Example: exists("person").
+	DependencyKindKey
+)
+
+// Dependencies walks the expression tree, returning every definition it depends on.
+// A definition is optional only if all of its usages are guarded:
+//   - exists and coalesce keys are optional.
+//   - references inside the then branch of if(exists("key"), ...) are optional, if they are key or one of its attributes.
+//   - references inside the expression of try are optional, if it catches definitionNotFound errors.
+//
+// Variables bound by projections, like it in sumOf(cart.items, it, it.price), are not definitions.
+//
+// The result is sorted by name and kind, and each name and kind pair appears only once.
+func Dependencies(root AstNode) []Dependency {
+	w := &dependencyWalker{
+		found: make(map[dependencyKey]bool),
+	}
+
+	w.walk(root, nil)
+
+	deps := make([]Dependency, 0, len(w.found))
+	for key, optional := range w.found {
+		deps = append(deps, Dependency{
+			Name:     key.name,
+			Kind:     key.kind,
+			Optional: optional,
+		})
+	}
+
+	slices.SortFunc(deps, func(a, b Dependency) int {
+		return cmp.Or(
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Kind, b.Kind),
+		)
+	})
+
+	return deps
+}
+
+// DependencyNames returns the unique names of the given dependencies, sorted.
+func DependencyNames(deps []Dependency) []string {
+	names := make([]string, 0, len(deps))
+	for i := range deps {
+		names = append(names, deps[i].Name)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+type (
+	dependencyKey struct {
+		name string
+		kind DependencyKind
+	}
+
+	dependencyWalker struct {
+		// found maps a dependency to whether it is optional.
+		found map[dependencyKey]bool
+		// locals are the variables bound by the enclosing projections, which aren't definitions.
+		locals []string
+	}
+)
+
+func (w *dependencyWalker) add(name string, kind DependencyKind, optional bool) {
+	key := dependencyKey{name: name, kind: kind}
+
+	prevOptional, ok := w.found[key]
+	w.found[key] = optional && (!ok || prevOptional)
+}
+
+func (w *dependencyWalker) walk(root AstNode, guards []string) {
+	switch node := root.(type) {
+	case Reference:
+		if isGuarded(node.Name, w.locals) {
+			return
+		}
+
+		w.add(node.Name, DependencyKindReference, isGuarded(node.Name, guards))
+	case Expression:
+		w.walkExpression(node, guards)
+	}
+}
+
+func (w *dependencyWalker) walkExpression(node Expression, guards []string) {
+	switch node.Scalar {
+	case "exists":
+		if key, ok := stringArg(node, 0); ok {
+			w.add(key, DependencyKindKey, true)
+		}
+		return
+	case "coalesce":
+		if key, ok := stringArg(node, 0); ok {
+			w.add(key, DependencyKindKey, true)
+		}
+
+		for _, arg := range node.KeyArgs[min(1, len(node.KeyArgs)):] {
+			w.walk(arg.Node, guards)
+		}
+		return
+	case "call":
+		if funcName, ok := stringArg(node, 0); ok {
+			w.add(funcName, DependencyKindFunction, isGuarded(funcName, guards))
+		}
+
+		for _, arg := range node.KeyArgs[min(1, len(node.KeyArgs)):] {
+			w.walk(arg.Node, guards)
+		}
+		return
+	case "if":
+		w.walkIf(node, guards)
+		return
+	case "try":
+		w.walkTry(node, guards)
+		return
+	}
+
+	if keys, ok := projectionKeys[node.Scalar]; ok {
+		w.walkProjection(node, keys, guards)
+		return
+	}
+
+	for _, arg := range node.KeyArgs {
+		if arg.Case != nil {
+			w.walk(arg.Case, guards)
+		}
+
+		w.walk(arg.Node, guards)
+	}
+}
+
+func (w *dependencyWalker) walkIf(node Expression, guards []string) {
+	indexes, _ := argIndexes(node, "condition", "then")
+
+	var thenGuards []string
+
+	if index, ok := indexes["condition"]; ok {
+		if condition, ok := node.KeyArgs[index].Node.(Expression); ok && condition.Scalar == "exists" {
+			if key, ok := stringArg(condition, 0); ok {
+				thenGuards = append(slices.Clip(guards), key)
+			}
+		}
+	}
+
+	for i, arg := range node.KeyArgs {
+		argGuards := guards
+
+		if index, ok := indexes["then"]; ok && i == index && thenGuards != nil {
+			argGuards = thenGuards
+		}
+
+		w.walk(arg.Node, argGuards)
+	}
+}
+
+// walkTry guards every reference of the expression, if try catches missing definitions.
+func (w *dependencyWalker) walkTry(node Expression, guards []string) {
+	// The error kinds are the arguments other than the expression and the fallback. Without kinds, every error is caught.
+	indexes, kinds := argIndexes(node, "expression", "fallback")
+	catchesNotFound := len(kinds) == 0
+
+	for _, i := range kinds {
+		if kind, ok := stringArg(node, i); ok && kind == "definitionNotFound" {
+			catchesNotFound = true
+		}
+	}
+
+	for i, arg := range node.KeyArgs {
+		argGuards := guards
+
+		if index, ok := indexes["expression"]; ok && i == index && catchesNotFound {
+			argGuards = append(slices.Clip(guards), guardAll)
+		}
+
+		w.walk(arg.Node, argGuards)
+	}
+}
+
+// projectionKeys maps the expressions binding a variable for their projection to their argument keys.
+var projectionKeys = map[string][]string{
+	"avgOf":        aggregateKeys,
+	"countOf":      aggregateKeys,
+	"distinct":     aggregateKeys,
+	"groupBy":      aggregateKeys,
+	"maxOf":        aggregateKeys,
+	"medianOf":     aggregateKeys,
+	"minOf":        aggregateKeys,
+	"percentileOf": {"collection", "percentile", "variable", "projection"},
+	"sort":         aggregateKeys,
+	"sortDesc":     aggregateKeys,
+	"stddevOf":     aggregateKeys,
+	"sumOf":        aggregateKeys,
+}
+
+var aggregateKeys = []string{"collection", "variable", "projection"}
+
+// walkProjection skips the variable of projections like sumOf(cart.items, it, it.price),
+// and the references to it from the projection.
+func (w *dependencyWalker) walkProjection(node Expression, keys []string, guards []string) {
+	indexes, _ := argIndexes(node, keys...)
+
+	variableIndex, hasVariable := indexes["variable"]
+	projectionIndex, hasProjection := indexes["projection"]
+
+	variable := ""
+	if hasVariable {
+		if reference, ok := node.KeyArgs[variableIndex].Node.(Reference); ok {
+			variable = reference.Name
+		}
+	}
+
+	for i, arg := range node.KeyArgs {
+		switch {
+		case hasVariable && i == variableIndex:
+			continue
+		case variable != "" && hasProjection && i == projectionIndex:
+			w.locals = append(w.locals, variable)
+			w.walk(arg.Node, guards)
+			w.locals = w.locals[:len(w.locals)-1]
+		default:
+			w.walk(arg.Node, guards)
+		}
+	}
+}
+
+// argIndexes returns the index of the argument of each key, matched like gonutils.SortArgs:
+// by name, or by position among the remaining keys when unnamed. The indexes of other arguments are returned in order.
+func argIndexes(node Expression, keys ...string) (map[string]int, []int) {
+	indexes := make(map[string]int, len(keys))
+	rest := make([]int, 0, len(node.KeyArgs))
+	keys = slices.Clone(keys)
+
+	for i, arg := range node.KeyArgs {
+		keyIndex := slices.IndexFunc(keys, func(key string) bool { return arg.Key == "" || arg.Key == key })
+		if keyIndex < 0 {
+			rest = append(rest, i)
+			continue
+		}
+
+		indexes[keys[keyIndex]] = i
+		keys = slices.Delete(keys, keyIndex, keyIndex+1)
+	}
+
+	return indexes, rest
+}
+
+func stringArg(node Expression, index int) (string, bool) {
+	if index >= len(node.KeyArgs) {
+		return "", false
+	}
+
+	literal, ok := node.KeyArgs[index].Node.(Literal)
+	if !ok {
+		return "", false
+	}
+
+	value, ok := literal.Value.(string)
+	return value, ok
+}
+
+// guardAll guards every definition, it is not a valid definition key.
+const guardAll = "*"
+
+func isGuarded(name string, guards []string) bool {
+	for _, guard := range guards {
+		if guard == guardAll || name == guard || strings.HasPrefix(name, guard+".") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,205 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/ast"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Dependencies(t *testing.T) {
+	parse := func(t *testing.T, rule string) ast.AstNode {
+		t.Helper()
+
+		node, err := encoding.Decode([]byte(rule), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		astNode, err := ast.Parse(node)
+		require.NoError(t, err)
+
+		return astNode
+	}
+
+	t.Run("should return required references", func(t *testing.T) {
+		astNode := parse(t, `if(gte(person.age, config.min_age), "pass", "fail")`)
+
+		expected := []ast.Dependency{
+			{Name: "config.min_age", Kind: ast.DependencyKindReference},
+			{Name: "person.age", Kind: ast.DependencyKindReference},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("should return function names", func(t *testing.T) {
+		astNode := parse(t, `call("greet", friend.name)`)
+
+		expected := []ast.Dependency{
+			{Name: "friend.name", Kind: ast.DependencyKindReference},
+			{Name: "greet", Kind: ast.DependencyKindFunction},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("exists and coalesce keys should be optional", func(t *testing.T) {
+		astNode := parse(t, `or(exists("premium"), coalesce("limit", default_limit))`)
+
+		expected := []ast.Dependency{
+			{Name: "default_limit", Kind: ast.DependencyKindReference},
+			{Name: "limit", Kind: ast.DependencyKindKey, Optional: true},
+			{Name: "premium", Kind: ast.DependencyKindKey, Optional: true},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("should skip projection variables", func(t *testing.T) {
+		astNode := parse(t, `gt(sumOf(cart.items, it, mul(it.price, tax.rate)), countOf(it))`)
+
+		expected := []ast.Dependency{
+			{Name: "cart.items", Kind: ast.DependencyKindReference},
+			{Name: "it", Kind: ast.DependencyKindReference},
+			{Name: "tax.rate", Kind: ast.DependencyKindReference},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("should skip projection variables of named arguments", func(t *testing.T) {
+		astNode := ast.Expression{
+			Scalar: "and",
+			KeyArgs: []ast.KeyNode{
+				{Node: ast.Expression{
+					Scalar: "sumOf",
+					KeyArgs: []ast.KeyNode{
+						{Key: "projection", Node: ast.Reference{Name: "it.price"}},
+						{Node: ast.Reference{Name: "cart.items"}},
+						{Node: ast.Reference{Name: "it"}},
+					},
+				}},
+				{Node: ast.Expression{
+					Scalar: "percentileOf",
+					KeyArgs: []ast.KeyNode{
+						{Node: ast.Reference{Name: "orders"}},
+						{Key: "variable", Node: ast.Reference{Name: "order"}},
+						{Key: "projection", Node: ast.Reference{Name: "order.total"}},
+						{Node: ast.Literal{Value: int64(90)}},
+					},
+				}},
+			},
+		}
+
+		expected := []ast.Dependency{
+			{Name: "cart.items", Kind: ast.DependencyKindReference},
+			{Name: "orders", Kind: ast.DependencyKindReference},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("references guarded by exists should be optional", func(t *testing.T) {
+		astNode := parse(t, `if(exists("connector"), connector.limit, fallback.limit)`)
+
+		expected := []ast.Dependency{
+			{Name: "connector", Kind: ast.DependencyKindKey, Optional: true},
+			{Name: "connector.limit", Kind: ast.DependencyKindReference, Optional: true},
+			{Name: "fallback.limit", Kind: ast.DependencyKindReference},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("guards should not match sibling keys", func(t *testing.T) {
+		astNode := parse(t, `if(exists("user"), user_id)`)
+
+		expected := []ast.Dependency{
+			{Name: "user", Kind: ast.DependencyKindKey, Optional: true},
+			{Name: "user_id", Kind: ast.DependencyKindReference},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("references guarded by try should be optional", func(t *testing.T) {
+		astNode := parse(t, `sum(try(user.discount, fallback.discount), try(user.bonus, 0, "typeMismatch"), try(user.extra, 0, "callFailure", "definitionNotFound"))`)
+
+		expected := []ast.Dependency{
+			{Name: "fallback.discount", Kind: ast.DependencyKindReference},
+			{Name: "user.bonus", Kind: ast.DependencyKindReference},
+			{Name: "user.discount", Kind: ast.DependencyKindReference, Optional: true},
+			{Name: "user.extra", Kind: ast.DependencyKindReference, Optional: true},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("guards should match named arguments in any order", func(t *testing.T) {
+		astNode := ast.Expression{
+			Scalar: "sum",
+			KeyArgs: []ast.KeyNode{
+				{Node: ast.Expression{
+					Scalar: "if",
+					KeyArgs: []ast.KeyNode{
+						{Key: "then", Node: ast.Reference{Name: "connector.limit"}},
+						{Key: "condition", Node: ast.Expression{
+							Scalar:  "exists",
+							KeyArgs: []ast.KeyNode{{Node: ast.Literal{Value: "connector"}}},
+						}},
+					},
+				}},
+				{Node: ast.Expression{
+					Scalar: "try",
+					KeyArgs: []ast.KeyNode{
+						{Key: "kind", Node: ast.Literal{Value: "definitionNotFound"}},
+						{Node: ast.Reference{Name: "user.bonus"}},
+						{Node: ast.Reference{Name: "fallback.bonus"}},
+					},
+				}},
+			},
+		}
+
+		expected := []ast.Dependency{
+			{Name: "connector", Kind: ast.DependencyKindKey, Optional: true},
+			{Name: "connector.limit", Kind: ast.DependencyKindReference, Optional: true},
+			{Name: "fallback.bonus", Kind: ast.DependencyKindReference},
+			{Name: "user.bonus", Kind: ast.DependencyKindReference, Optional: true},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("unguarded usages should make the dependency required", func(t *testing.T) {
+		astNode := parse(t, `or(if(exists("user"), user.admin), user.admin)`)
+
+		deps := ast.Dependencies(astNode)
+		require.Contains(t, deps, ast.Dependency{Name: "user.admin", Kind: ast.DependencyKindReference})
+	})
+
+	t.Run("should walk nodes built from code", func(t *testing.T) {
+		astNode, err := ast.Parse(gon.Not(gon.Reference("flag")))
+		require.NoError(t, err)
+
+		expected := []ast.Dependency{
+			{Name: "flag", Kind: ast.DependencyKindReference},
+		}
+
+		require.Equal(t, expected, ast.Dependencies(astNode))
+	})
+
+	t.Run("literals have no dependencies", func(t *testing.T) {
+		require.Empty(t, ast.Dependencies(ast.Literal{Value: 1}))
+	})
+}
+
+func Test_DependencyNames(t *testing.T) {
+	deps := []ast.Dependency{
+		{Name: "b", Kind: ast.DependencyKindReference},
+		{Name: "a", Kind: ast.DependencyKindKey, Optional: true},
+		{Name: "a", Kind: ast.DependencyKindReference},
+	}
+
+	require.Equal(t, []string{"a", "b"}, ast.DependencyNames(deps))
+}
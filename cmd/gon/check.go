@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/sonalys/gon/encoding"
+)
+
+// runCheck decodes rule files, reporting syntax and codex errors with their positions.
+func runCheck(args []string, stdin io.Reader, _, stderr io.Writer) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gon check [files...]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+
+	exitCode := 0
+
+	for _, path := range paths {
+		src, name, err := readSource(path, stdin)
+		if err == nil {
+			_, err = encoding.Decode(src, encoding.DefaultExpressionCodex)
+		}
+
+		if err != nil {
+			reportError(stderr, "check", name, err)
+			exitCode = 1
+		}
+	}
+
+	return exitCode
+}
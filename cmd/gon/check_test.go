@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Check(t *testing.T) {
+	t.Run("should accept valid rules", func(t *testing.T) {
+		stdout, stderr, exitCode := execute(t, `not(flag)`, "check")
+		require.Equal(t, 0, exitCode)
+		require.Empty(t, stdout)
+		require.Empty(t, stderr)
+	})
+
+	t.Run("should report syntax errors with position", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "if(\n\tflag: )", "check")
+		require.Equal(t, 1, exitCode)
+		require.Equal(t, "gon check: <stdin>:2:8: unexpected ')'\n", stderr)
+	})
+
+	t.Run("should report codex errors with position", func(t *testing.T) {
+		dir := t.TempDir()
+		valid := filepath.Join(dir, "valid.gon")
+		invalid := filepath.Join(dir, "invalid.gon")
+
+		require.NoError(t, os.WriteFile(valid, []byte(`not(flag)`), 0o600))
+		require.NoError(t, os.WriteFile(invalid, []byte("not(\n  unknown(flag))"), 0o600))
+
+		_, stderr, exitCode := execute(t, "", "check", valid, invalid)
+		require.Equal(t, 1, exitCode)
+		require.Equal(t, "gon check: "+invalid+":2:3: codex for 'unknown' not found\n", stderr)
+	})
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/encoding"
+)
+
+// maxCompletionDepth limits the definition paths collected from nested values, which can be recursive.
+const maxCompletionDepth = 5
+
+// completer suggests codex scalars, definition paths and repl commands.
+type completer struct {
+	codex  encoding.Codex
+	values gon.Values
+}
+
+// complete is an AutoCompleteCallback for term.Terminal.
+// It completes the word before the cursor with the longest prefix shared by all candidates.
+func (c *completer) complete(line string, pos int, key rune) (newLine string, newPos int, ok bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	start := pos
+	for start > 0 && isWordByte(line[start-1]) {
+		start--
+	}
+
+	word := line[start:pos]
+
+	var candidates []string
+	if strings.HasPrefix(line, ":") && !strings.Contains(line[:pos], " ") {
+		word = line[:pos]
+		candidates = c.commands(word)
+	} else {
+		candidates = c.candidates(word)
+	}
+
+	completion := commonPrefix(candidates)
+	if len(completion) <= len(word) {
+		return "", 0, false
+	}
+
+	start = pos - len(word)
+
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
+
+// candidates returns the scalars and definition paths starting with prefix, sorted.
+func (c *completer) candidates(prefix string) []string {
+	var candidates []string
+
+	for scalar := range c.codex {
+		if strings.HasPrefix(scalar, prefix) {
+			candidates = append(candidates, scalar+"(")
+		}
+	}
+
+	for key, value := range c.values {
+		for _, path := range definitionPaths(key, reflect.ValueOf(value.Value()), maxCompletionDepth) {
+			if strings.HasPrefix(path, prefix) {
+				candidates = append(candidates, path)
+			}
+		}
+	}
+
+	slices.Sort(candidates)
+
+	return candidates
+}
+
+func (c *completer) commands(prefix string) []string {
+	var candidates []string
+
+	for _, cmd := range replCommands {
+		if strings.HasPrefix(cmd.name, prefix) {
+			candidates = append(candidates, cmd.name+" ")
+		}
+	}
+
+	return candidates
+}
+
+// definitionPaths returns the path, and the paths of the struct fields tagged with gon, and of map keys.
+func definitionPaths(path string, value reflect.Value, depth int) []string {
+	paths := []string{path}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if depth == 0 || !value.IsValid() {
+		return paths
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		typeOf := value.Type()
+		for i := range typeOf.NumField() {
+			tag := typeOf.Field(i).Tag.Get("gon")
+			if tag == "" || !typeOf.Field(i).IsExported() {
+				continue
+			}
+
+			paths = append(paths, definitionPaths(path+"."+tag, value.Field(i), depth-1)...)
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		for iter := value.MapRange(); iter.Next(); {
+			paths = append(paths, definitionPaths(path+"."+iter.Key().String(), iter.Value(), depth-1)...)
+		}
+	}
+
+	return paths
+}
+
+func commonPrefix(candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	prefix := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '-' || b == '.' ||
+		'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Completer(t *testing.T) {
+	type address struct {
+		City string `gon:"city"`
+		Zip  string `gon:"zip"`
+	}
+
+	type person struct {
+		Name    string   `gon:"name"`
+		Address *address `gon:"address"`
+		hidden  string   `gon:"hidden"`
+		Ignored string
+	}
+
+	c := &completer{
+		codex: encoding.DefaultExpressionCodex,
+		values: gon.Values{
+			"person": gon.Literal(person{Address: &address{}, hidden: "unused"}),
+			"config": gon.Literal(map[string]any{"limit": 10}),
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		line     string
+		pos      int
+		expected string
+		ok       bool
+	}{
+		{name: "should complete scalars", line: "hasP", pos: 4, expected: "hasPrefix(", ok: true},
+		{name: "should complete common prefix", line: "has", pos: 3, expected: "hasPrefix(", ok: false},
+		{name: "should complete struct fields", line: "equal(person.na", pos: 15, expected: "equal(person.name", ok: true},
+		{name: "should complete nested pointer fields", line: "person.address.c", pos: 16, expected: "person.address.city", ok: true},
+		{name: "should complete map keys", line: "config.l", pos: 8, expected: "config.limit", ok: true},
+		{name: "should complete before cursor", line: "not(pers)", pos: 8, expected: "not(person)", ok: true},
+		{name: "should complete commands", line: ":exp", pos: 4, expected: ":explain ", ok: true},
+		{name: "should not complete unknown words", line: "unknown", pos: 7, ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, _, ok := c.complete(tc.line, tc.pos, '\t')
+			require.Equal(t, tc.ok, ok)
+
+			if ok {
+				require.Equal(t, tc.expected, line)
+			}
+		})
+	}
+
+	t.Run("should ignore other keys", func(t *testing.T) {
+		_, _, ok := c.complete("hasP", 4, 'a')
+		require.False(t, ok)
+	})
+
+	t.Run("should skip unexported and untagged fields", func(t *testing.T) {
+		require.Equal(t, []string{"person", "person.address", "person.address.city", "person.address.zip", "person.name"}, c.candidates("person"))
+	})
+}
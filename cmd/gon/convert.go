@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
+	"github.com/sonalys/gon/encoding"
+)
+
+// format is a rule encoding supported by convert.
+type format struct {
+	decode func(src []byte) (adapters.Node, ast.CommentMap, error)
+	encode func(w io.Writer, root adapters.Node, opts ...encoding.HumanEncodeOption) error
+}
+
+func decodeText(src []byte) (adapters.Node, ast.CommentMap, error) {
+	return encoding.DecodeWithComments(src, encoding.DefaultExpressionCodex)
+}
+
+func decodeInfix(src []byte) (adapters.Node, ast.CommentMap, error) {
+	node, err := encoding.DecodeInfix(src, encoding.DefaultExpressionCodex)
+	return node, nil, err
+}
+
+var formats = map[string]format{
+	"text": {
+		decode: decodeText,
+		encode: encoding.HumanEncode,
+	},
+	"compact": {
+		decode: decodeText,
+		encode: func(w io.Writer, root adapters.Node, opts ...encoding.HumanEncodeOption) error {
+			return encoding.HumanEncode(w, root, append(opts, encoding.Compact())...)
+		},
+	},
+	"infix": {
+		decode: decodeInfix,
+		encode: func(w io.Writer, root adapters.Node, opts ...encoding.HumanEncodeOption) error {
+			return encoding.HumanEncode(w, root, append(opts, encoding.Infix())...)
+		},
+	},
+}
+
+func formatNames() string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return strings.Join(names, ", ")
+}
+
+// runConvert decodes a rule in one format, and encodes it in another.
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gon convert [flags] [file]")
+		flags.PrintDefaults()
+	}
+
+	from := flags.String("from", "text", "input format: "+formatNames())
+	to := flags.String("to", "text", "output format: "+formatNames())
+	unnamed := flags.Bool("unnamed", false, "omit parameter names")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	input, ok := formats[*from]
+	if !ok {
+		fmt.Fprintf(stderr, "gon convert: unknown format '%s'\n", *from)
+		return 2
+	}
+
+	output, ok := formats[*to]
+	if !ok {
+		fmt.Fprintf(stderr, "gon convert: unknown format '%s'\n", *to)
+		return 2
+	}
+
+	src, name, err := readSource(flags.Arg(0), stdin)
+	if err != nil {
+		reportError(stderr, "convert", name, err)
+		return 1
+	}
+
+	rule, comments, err := input.decode(src)
+	if err != nil {
+		reportError(stderr, "convert", name, err)
+		return 1
+	}
+
+	opts := []encoding.HumanEncodeOption{encoding.WithComments(comments)}
+	if *unnamed {
+		opts = append(opts, encoding.Unnamed())
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	if err := output.encode(buf, rule, opts...); err != nil {
+		reportError(stderr, "convert", name, err)
+		return 1
+	}
+
+	buf.WriteByte('\n')
+
+	_, _ = stdout.Write(buf.Bytes())
+
+	return 0
+}
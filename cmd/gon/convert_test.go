@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Convert(t *testing.T) {
+	const rule = "// adults\nif(gte(person.age, 18), \"pass\")"
+
+	t.Run("should convert to compact", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, rule, "convert", "-to", "compact", "-unnamed")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "/* adults */ if(gte(person.age,18),\"pass\")\n", stdout)
+	})
+
+	t.Run("should convert to text", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, `if(gte(person.age,18),"pass")`, "convert", "-from", "compact")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "if(condition: gte(first: person.age, second: 18), then: \"pass\")\n", stdout)
+	})
+
+	t.Run("should convert to and from infix", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, rule, "convert", "-to", "infix")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "if(condition: person.age >= 18, then: \"pass\")\n", stdout)
+
+		stdout, _, exitCode = execute(t, `person.age >= 18 ? "pass" : "fail"`, "convert", "-from", "infix", "-to", "compact", "-unnamed")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "if(gte(person.age,18),\"pass\",\"fail\")\n", stdout)
+	})
+
+	t.Run("should fail on unknown formats", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, rule, "convert", "-to", "xml")
+		require.Equal(t, 2, exitCode)
+		require.Contains(t, stderr, "unknown format 'xml'")
+	})
+
+	t.Run("should fail on invalid rules", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "if(", "convert")
+		require.Equal(t, 1, exitCode)
+		require.Contains(t, stderr, "<stdin>:1:1")
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/encoding"
+)
+
+// runEval decodes a rule and prints its result, computed against the values file.
+func runEval(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("eval", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gon eval [flags] [file]")
+		flags.PrintDefaults()
+	}
+
+	valuesPath := flags.String("values", "", "JSON or YAML file with the definitions, selected by the extension")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	scope := gon.NewScope()
+
+	if *valuesPath != "" {
+		values, err := loadValues(*valuesPath)
+		if err == nil {
+			scope, err = scope.WithValues(values)
+		}
+
+		if err != nil {
+			reportError(stderr, "eval", *valuesPath, err)
+			return 1
+		}
+	}
+
+	src, name, err := readSource(flags.Arg(0), stdin)
+	if err != nil {
+		reportError(stderr, "eval", name, err)
+		return 1
+	}
+
+	rule, spans, err := encoding.DecodeWithSpans(src, encoding.DefaultExpressionCodex)
+	if err != nil {
+		reportError(stderr, "eval", name, err)
+		return 1
+	}
+
+	value, err := scope.Compute(rule)
+	if err != nil {
+		reportError(stderr, "eval", name, spans.Locate(err))
+		return 1
+	}
+
+	fmt.Fprintln(stdout, value)
+
+	return 0
+}
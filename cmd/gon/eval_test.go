@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Eval(t *testing.T) {
+	const rule = `if(gte(person.age, 18), person.name, "minor")`
+
+	writeFile := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("should evaluate with JSON values", func(t *testing.T) {
+		values := writeFile(t, "values.json", `{"person": {"name": "alice", "age": 19}}`)
+
+		stdout, stderr, exitCode := execute(t, rule, "eval", "-values", values)
+		require.Equal(t, 0, exitCode, stderr)
+		require.Equal(t, "alice\n", stdout)
+	})
+
+	t.Run("should evaluate with YAML values", func(t *testing.T) {
+		values := writeFile(t, "values.yaml", "person:\n  name: bob\n  age: 17\n")
+
+		stdout, stderr, exitCode := execute(t, rule, "eval", "-values", values)
+		require.Equal(t, 0, exitCode, stderr)
+		require.Equal(t, "minor\n", stdout)
+	})
+
+	t.Run("should evaluate rule files", func(t *testing.T) {
+		path := writeFile(t, "rule.gon", `sum(1.5, 2.0)`)
+
+		stdout, _, exitCode := execute(t, "", "eval", path)
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "3.5\n", stdout)
+	})
+
+	t.Run("should fail on evaluation errors", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, rule, "eval")
+		require.Equal(t, 1, exitCode)
+		require.Equal(t, "gon eval: <stdin>:1:8: if.condition.gte.first: definition 'person.age' not found\n", stderr)
+	})
+
+	t.Run("should fail on invalid values", func(t *testing.T) {
+		values := writeFile(t, "values.json", `[1, 2]`)
+
+		_, stderr, exitCode := execute(t, rule, "eval", "-values", values)
+		require.Equal(t, 1, exitCode)
+		require.Contains(t, stderr, "decoding json values")
+	})
+}
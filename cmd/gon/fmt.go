@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sonalys/gon/encoding"
+)
+
+// runFmt reformats rule files, or the standard input when no files are given.
+func runFmt(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gon fmt [flags] [files...]")
+		flags.PrintDefaults()
+	}
+
+	write := flags.Bool("w", false, "write the result to the source file instead of the standard output")
+	list := flags.Bool("l", false, "list files whose formatting differs from the canonical layout")
+	width := flags.Int("width", encoding.DefaultLineWidth, "maximum line width")
+	compact := flags.Bool("compact", false, "write each rule in a single line")
+	unnamed := flags.Bool("unnamed", false, "omit parameter names")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := []encoding.HumanEncodeOption{encoding.LineWidth(*width)}
+	if *compact {
+		opts = append(opts, encoding.Compact())
+	}
+	if *unnamed {
+		opts = append(opts, encoding.Unnamed())
+	}
+
+	if flags.NArg() == 0 {
+		if *write {
+			fmt.Fprintln(stderr, "gon fmt: cannot use -w with standard input")
+			return 2
+		}
+
+		if err := formatFile("", stdin, *write, *list, stdout, opts); err != nil {
+			reportError(stderr, "fmt", stdinName, err)
+			return 1
+		}
+
+		return 0
+	}
+
+	exitCode := 0
+
+	for _, path := range flags.Args() {
+		if err := formatFile(path, stdin, *write, *list, stdout, opts); err != nil {
+			reportError(stderr, "fmt", path, err)
+			exitCode = 1
+		}
+	}
+
+	return exitCode
+}
+
+func formatFile(path string, stdin io.Reader, write, list bool, stdout io.Writer, opts []encoding.HumanEncodeOption) error {
+	src, name, err := readSource(path, stdin)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := encoding.Format(src, encoding.DefaultExpressionCodex, opts...)
+	if err != nil {
+		return err
+	}
+
+	changed := !bytes.Equal(src, formatted)
+
+	if list && changed {
+		fmt.Fprintln(stdout, name)
+	}
+
+	switch {
+	case write && changed:
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(path, formatted, info.Mode().Perm())
+	case !write && !list:
+		_, err = stdout.Write(formatted)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Fmt(t *testing.T) {
+	const (
+		rule      = `if(gte(person.age, 18), "pass", "fail")`
+		formatted = "if(condition: gte(first: person.age, second: 18), then: \"pass\", else: \"fail\")\n"
+	)
+
+	t.Run("should format standard input", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, rule, "fmt")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, formatted, stdout)
+	})
+
+	t.Run("should apply flags", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, rule, "fmt", "-compact", "-unnamed")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "if(gte(person.age,18),\"pass\",\"fail\")\n", stdout)
+	})
+
+	t.Run("should preserve comments", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, "// adults\n"+rule, "fmt")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "// adults\n"+formatted, stdout)
+	})
+
+	t.Run("should fail on invalid rules", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "unknown(1)", "fmt")
+		require.Equal(t, 1, exitCode)
+		require.Contains(t, stderr, "<stdin>")
+	})
+
+	t.Run("should write files in place", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rule.gon")
+		require.NoError(t, os.WriteFile(path, []byte(rule), 0o600))
+
+		stdout, _, exitCode := execute(t, "", "fmt", "-w", path)
+		require.Equal(t, 0, exitCode)
+		require.Empty(t, stdout)
+
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, formatted, string(got))
+	})
+
+	t.Run("should list unformatted files", func(t *testing.T) {
+		dir := t.TempDir()
+		unformatted := filepath.Join(dir, "unformatted.gon")
+		canonical := filepath.Join(dir, "canonical.gon")
+
+		require.NoError(t, os.WriteFile(unformatted, []byte(rule), 0o600))
+		require.NoError(t, os.WriteFile(canonical, []byte(formatted), 0o600))
+
+		stdout, _, exitCode := execute(t, "", "fmt", "-l", unformatted, canonical)
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, unformatted+"\n", stdout)
+	})
+
+	t.Run("should report missing files", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "", "fmt", filepath.Join(t.TempDir(), "missing.gon"))
+		require.Equal(t, 1, exitCode)
+		require.Contains(t, stderr, "missing.gon")
+	})
+}
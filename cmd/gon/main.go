@@ -0,0 +1,72 @@
+// Command gon is a command-line tool for working with gon rules.
+//
+// Usage:
+//
+//	gon <command> [arguments]
+//
+// Run 'gon help' for the list of commands.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name  string
+	short string
+	run   func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// commands is populated by init, since the help command references it.
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "check", short: "report syntax and codex errors in rule files", run: runCheck},
+		{name: "convert", short: "convert a rule between encodings", run: runConvert},
+		{name: "eval", short: "evaluate a rule against a JSON or YAML values file", run: runEval},
+		{name: "fmt", short: "reformat rule files in the canonical layout", run: runFmt},
+		{name: "help", short: "show this help", run: runHelp},
+		{name: "repl", short: "start an interactive session for evaluating rules", run: runRepl},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line, returning the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "gon: unknown command '%s'\n", args[0])
+	printUsage(stderr)
+
+	return 2
+}
+
+func runHelp(_ []string, _ io.Reader, stdout, _ io.Writer) int {
+	printUsage(stdout)
+	return 0
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gon <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.short)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func execute(t *testing.T, stdin string, args ...string) (stdout, stderr string, exitCode int) {
+	t.Helper()
+
+	outBuf := bytes.NewBuffer(nil)
+	errBuf := bytes.NewBuffer(nil)
+
+	exitCode = run(args, strings.NewReader(stdin), outBuf, errBuf)
+
+	return outBuf.String(), errBuf.String(), exitCode
+}
+
+func Test_Run(t *testing.T) {
+	t.Run("should fail without command", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "")
+		require.Equal(t, 2, exitCode)
+		require.Contains(t, stderr, "Usage")
+	})
+
+	t.Run("should fail on unknown command", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "", "unknown")
+		require.Equal(t, 2, exitCode)
+		require.Contains(t, stderr, "unknown command 'unknown'")
+	})
+
+	t.Run("should print help", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, "", "help")
+		require.Equal(t, 0, exitCode)
+		require.Contains(t, stdout, "fmt")
+	})
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
+	"github.com/sonalys/gon/encoding"
+	"golang.org/x/term"
+)
+
+const replPrompt = "gon> "
+
+type replCommand struct {
+	name  string
+	usage string
+	run   func(r *repl, arg string) error
+}
+
+// replCommands is populated by init, since the help command references it.
+var replCommands []replCommand
+
+func init() {
+	replCommands = []replCommand{
+		{name: ":ast", usage: ":ast <expr>            print the ast of the expression", run: (*repl).ast},
+		{name: ":explain", usage: ":explain <expr>        print the value of each sub-expression", run: (*repl).explain},
+		{name: ":help", usage: ":help                  show this help", run: (*repl).help},
+		{name: ":let", usage: ":let <name> = <expr>   define name with the value of the expression", run: (*repl).let},
+		{name: ":load", usage: ":load <file>           load definitions from a JSON or YAML file", run: (*repl).load},
+		{name: ":quit", usage: ":quit                  exit", run: (*repl).quit},
+	}
+}
+
+var errQuit = errors.New("quit")
+
+// repl evaluates expressions against the definitions loaded during the session.
+type repl struct {
+	out    io.Writer
+	values gon.Values
+	codex  encoding.Codex
+}
+
+// runRepl starts an interactive session.
+// When the standard input is not a terminal, the lines are read without prompts or completion.
+func runRepl(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("repl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gon repl [flags]")
+		flags.PrintDefaults()
+	}
+
+	valuesPath := flags.String("values", "", "JSON or YAML file with the initial definitions")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	r := &repl{
+		out:    stdout,
+		values: gon.Values{},
+		codex:  encoding.DefaultExpressionCodex,
+	}
+
+	if *valuesPath != "" {
+		if err := r.load(*valuesPath); err != nil {
+			reportError(stderr, "repl", *valuesPath, err)
+			return 1
+		}
+	}
+
+	readLine, restore, err := r.lineReader(stdin, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "gon repl: %s\n", err)
+		return 1
+	}
+	defer restore()
+
+	for {
+		line, err := readLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0
+			}
+
+			fmt.Fprintf(r.out, "error: %s\n", err)
+			return 1
+		}
+
+		if err := r.execute(line); err != nil {
+			if errors.Is(err, errQuit) {
+				return 0
+			}
+
+			fmt.Fprintf(r.out, "error: %s\n", err)
+		}
+	}
+}
+
+// lineReader returns a line editor with completion for terminals, or a plain line scanner otherwise.
+func (r *repl) lineReader(stdin io.Reader, stdout io.Writer) (readLine func() (string, error), restore func(), err error) {
+	file, isFile := stdin.(*os.File)
+	if !isFile || !term.IsTerminal(int(file.Fd())) {
+		scanner := bufio.NewScanner(stdin)
+
+		return func() (string, error) {
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return "", err
+				}
+
+				return "", io.EOF
+			}
+
+			return scanner.Text(), nil
+		}, func() {}, nil
+	}
+
+	state, err := term.MakeRaw(int(file.Fd()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{stdin, stdout}, replPrompt)
+
+	completer := &completer{codex: r.codex, values: r.values}
+	terminal.AutoCompleteCallback = completer.complete
+
+	// The terminal translates new lines for the raw mode.
+	r.out = terminal
+
+	return terminal.ReadLine, func() { _ = term.Restore(int(file.Fd()), state) }, nil
+}
+
+// execute runs a command, or evaluates the line as an expression.
+func (r *repl) execute(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(line, ":") {
+		value, err := r.eval(line)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(r.out, formatValue(value))
+		return nil
+	}
+
+	name, arg, _ := strings.Cut(line, " ")
+
+	for _, cmd := range replCommands {
+		if cmd.name == name {
+			return cmd.run(r, strings.TrimSpace(arg))
+		}
+	}
+
+	return fmt.Errorf("unknown command '%s', see :help", name)
+}
+
+func (r *repl) decode(expr string) (adapters.Node, error) {
+	if expr == "" {
+		return nil, errors.New("missing expression")
+	}
+
+	return encoding.Decode([]byte(expr), r.codex)
+}
+
+func (r *repl) eval(expr string) (any, error) {
+	node, err := r.decode(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.compute(node)
+}
+
+func (r *repl) compute(node adapters.Node) (any, error) {
+	scope, err := gon.NewScope().WithValues(r.values)
+	if err != nil {
+		return nil, err
+	}
+
+	return scope.Compute(node)
+}
+
+func (r *repl) load(path string) error {
+	values, err := loadValues(path)
+	if err != nil {
+		return err
+	}
+
+	// Validates the keys before merging them.
+	if _, err := gon.NewScope().WithValues(values); err != nil {
+		return err
+	}
+
+	maps.Copy(r.values, values)
+
+	return nil
+}
+
+func (r *repl) let(arg string) error {
+	name, expr, ok := strings.Cut(arg, "=")
+	if !ok {
+		return errors.New("usage: :let <name> = <expr>")
+	}
+
+	name = strings.TrimSpace(name)
+
+	value, err := r.eval(strings.TrimSpace(expr))
+	if err != nil {
+		return err
+	}
+
+	values := gon.Values{name: gon.Literal(value)}
+	if _, err := gon.NewScope().WithValues(values); err != nil {
+		return err
+	}
+
+	maps.Copy(r.values, values)
+
+	return nil
+}
+
+// explain prints each sub-expression with its value, indented by depth.
+func (r *repl) explain(expr string) error {
+	node, err := r.decode(expr)
+	if err != nil {
+		return err
+	}
+
+	return r.explainNode(node, 0)
+}
+
+func (r *repl) explainNode(node adapters.Node, depth int) error {
+	serializable, ok := node.(adapters.SerializableNode)
+	if !ok {
+		return fmt.Errorf("cannot explain node %T", node)
+	}
+
+	// Literals are their own values.
+	if serializable.Type() == adapters.NodeTypeLiteral {
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := encoding.HumanEncode(buf, node, encoding.Compact(), encoding.Unnamed()); err != nil {
+		return err
+	}
+
+	result := "error: "
+	if value, err := r.compute(node); err != nil {
+		result += err.Error()
+	} else {
+		result = formatValue(value)
+	}
+
+	fmt.Fprintf(r.out, "%s%s => %s\n", strings.Repeat("  ", depth), buf, result)
+
+	if serializable.Type() != adapters.NodeTypeExpression {
+		return nil
+	}
+
+	for _, arg := range serializable.Shape() {
+		if arg.Case != nil {
+			if err := r.explainNode(arg.Case, depth+1); err != nil {
+				return err
+			}
+		}
+
+		if err := r.explainNode(arg.Node, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ast prints the tree returned by ast.Parse.
+func (r *repl) ast(expr string) error {
+	node, err := r.decode(expr)
+	if err != nil {
+		return err
+	}
+
+	root, err := ast.Parse(node)
+	if err != nil {
+		return err
+	}
+
+	printAst(r.out, root, "", 0)
+
+	return nil
+}
+
+func printAst(w io.Writer, node ast.AstNode, key string, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if key != "" {
+		key += ": "
+	}
+
+	switch node := node.(type) {
+	case ast.Expression:
+		fmt.Fprintf(w, "%s%sExpression %s\n", indent, key, node.Scalar)
+
+		for _, arg := range node.KeyArgs {
+			if arg.Case != nil {
+				printAst(w, arg.Case, "case", depth+1)
+			}
+
+			printAst(w, arg.Node, arg.Key, depth+1)
+		}
+	case ast.Reference:
+		fmt.Fprintf(w, "%s%sReference %s\n", indent, key, node.Name)
+	case ast.Literal:
+		fmt.Fprintf(w, "%s%sLiteral %s (%T)\n", indent, key, formatValue(node.Value), node.Value)
+	case ast.Invalid:
+		fmt.Fprintf(w, "%s%sInvalid %s\n", indent, key, node.Error)
+	}
+}
+
+func (r *repl) help(string) error {
+	for _, cmd := range replCommands {
+		fmt.Fprintln(r.out, cmd.usage)
+	}
+
+	fmt.Fprintln(r.out, "Any other input is evaluated as an expression.")
+
+	return nil
+}
+
+func (r *repl) quit(string) error {
+	return errQuit
+}
+
+func formatValue(value any) string {
+	if str, ok := value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Repl(t *testing.T) {
+	values := filepath.Join(t.TempDir(), "values.json")
+	require.NoError(t, os.WriteFile(values, []byte(`{"person": {"name": "alice", "age": 19}}`), 0o600))
+
+	t.Run("should evaluate expressions", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, "sum(1, 2)\n\nequal(\"a\", \"a\")\n", "repl")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "3\ntrue\n", stdout)
+	})
+
+	t.Run("should load values", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, ":load "+values+"\nperson.name\n", "repl")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "\"alice\"\n", stdout)
+	})
+
+	t.Run("should load values from flag", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, "person.age\n", "repl", "-values", values)
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "19\n", stdout)
+	})
+
+	t.Run("should define values", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, ":let adult = gte(person.age, 18)\nadult\n", "repl", "-values", values)
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, "true\n", stdout)
+	})
+
+	t.Run("should reject invalid names", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, ":let x = 1\n:let adult\n", "repl")
+		require.Equal(t, 0, exitCode)
+		require.Contains(t, stdout, "error: definition key 'x' is invalid")
+		require.Contains(t, stdout, "error: usage: :let <name> = <expr>")
+	})
+
+	t.Run("should explain expressions", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, `:explain if(gte(person.age, 18), person.name, "minor")`, "repl", "-values", values)
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, `if(gte(person.age,18),person.name,"minor") => "alice"
+  gte(person.age,18) => true
+    person.age => 19
+  person.name => "alice"
+`, stdout)
+	})
+
+	t.Run("should explain errors", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, `:explain not(missing)`, "repl")
+		require.Equal(t, 0, exitCode)
+		require.Contains(t, stdout, "not(missing) => error: ")
+		require.Contains(t, stdout, "  missing => error: ")
+	})
+
+	t.Run("should print the ast", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, `:ast if(gte(person.age, 18), "pass")`, "repl")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, `Expression if
+  condition: Expression gte
+    first: Reference person.age
+    second: Literal 18 (int64)
+  then: Literal "pass" (string)
+`, stdout)
+	})
+
+	t.Run("should report errors and continue", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, "if(\n:unknown\n:ast\n1\n", "repl")
+		require.Equal(t, 0, exitCode)
+		require.Equal(t, `error: parsing input: 1:1: missing ')' for 'if'
+error: unknown command ':unknown', see :help
+error: missing expression
+1
+`, stdout)
+	})
+
+	t.Run("should stop on quit", func(t *testing.T) {
+		stdout, _, exitCode := execute(t, ":quit\n1\n", "repl")
+		require.Equal(t, 0, exitCode)
+		require.Empty(t, stdout)
+	})
+
+	t.Run("should fail on invalid values file", func(t *testing.T) {
+		_, stderr, exitCode := execute(t, "", "repl", "-values", filepath.Join(t.TempDir(), "missing.json"))
+		require.Equal(t, 1, exitCode)
+		require.Contains(t, stderr, "missing.json")
+	})
+}
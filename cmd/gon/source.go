@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+)
+
+const stdinName = "<stdin>"
+
+// readSource reads the file, or the standard input when the path is empty or '-'.
+// It returns the name used for reporting errors.
+func readSource(path string, stdin io.Reader) (src []byte, name string, err error) {
+	if path == "" || path == "-" {
+		src, err = io.ReadAll(stdin)
+		return src, stdinName, err
+	}
+
+	src, err = os.ReadFile(path)
+	return src, path, err
+}
+
+// reportError writes the error prefixed by the source name, and by the position of syntax errors or located node errors.
+func reportError(w io.Writer, command, name string, err error) {
+	var syntaxErr encoding.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		fmt.Fprintf(w, "gon %s: %s:%s: %s\n", command, name, syntaxErr.Position, syntaxErr.Cause)
+		return
+	}
+
+	if nodeErr, ok := adapters.AsNodeError(err); ok && nodeErr.Span != nil {
+		fmt.Fprintf(w, "gon %s: %s:%s: %s\n", command, name, nodeErr.Span, nodeErr)
+		return
+	}
+
+	fmt.Fprintf(w, "gon %s: %s: %s\n", command, name, err)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/sonalys/gon"
+)
+
+// loadValues reads a JSON or YAML document, depending on the file extension.
+func loadValues(path string) (gon.Values, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return gon.ValuesFromYAML(file)
+	default:
+		return gon.ValuesFromJSON(file)
+	}
+}
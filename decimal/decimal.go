@@ -0,0 +1,300 @@
+// Package decimal implements arbitrary-precision decimal numbers on top of math/big.
+// Decimals are exact for addition, subtraction and multiplication, so 0.1 + 0.2 equals 0.3, as required by money rules.
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+// Decimal is an immutable decimal number, represented by an unscaled integer and the number of digits after the point.
+// The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// DivisionScale is the number of extra digits kept by Div, for quotients without an exact decimal representation.
+// Example: 1 / 3 is 0.3333333333333333.
+var DivisionScale int32 = 16
+
+// MaxDigits bounds the digits of Pow results, and the places rules can round to, so rules can't grow decimals to unbounded sizes.
+// Example: 1.5^100000000 overflows.
+var MaxDigits int32 = 1000
+
+var ten = big.NewInt(10)
+
+// New returns the decimal unscaled * 10^-scale. Example: New(1250, 2) is 12.50.
+func New(unscaled int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// FromInt returns the decimal of an integer.
+func FromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// FromFloat returns the decimal of the shortest representation of the float. Example: 0.1 is 0.1, and not 0.1000000000000000055511151231257827.
+func FromFloat(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, fmt.Errorf("cannot convert %v to decimal", value)
+	}
+
+	return Parse(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Parse parses a decimal written as an optional sign, digits, and an optional fraction. Example: -12.50.
+func Parse(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+
+	integer, fraction, hasPoint := strings.Cut(digits, ".")
+	if integer == "" && fraction == "" || hasPoint && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+
+	if strings.HasPrefix(s, "-") {
+		unscaled.Neg(unscaled)
+	}
+
+	return newDecimal(unscaled, int32(len(fraction))), nil
+}
+
+// MustParse is like Parse, but panics if the decimal is invalid.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newDecimal(unscaled *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(unscaled, pow10(-scale))}
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+func pow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(exponent)), nil)
+}
+
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.unscaled
+}
+
+// rescaled returns the unscaled value with the given scale, which should not be lower than the decimal scale.
+func (d Decimal) rescaled(scale int32) *big.Int {
+	return new(big.Int).Mul(d.value(), pow10(scale-d.scale))
+}
+
+// Scale returns the number of digits after the point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1, for negative, zero and positive decimals.
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero reports whether the decimal is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Add returns d + other, with the largest scale of both.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return newDecimal(new(big.Int).Add(d.rescaled(scale), other.rescaled(scale)), scale)
+}
+
+// Sub returns d - other, with the largest scale of both.
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return newDecimal(new(big.Int).Sub(d.rescaled(scale), other.rescaled(scale)), scale)
+}
+
+// Mul returns d * other, with the sum of both scales.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return newDecimal(new(big.Int).Mul(d.value(), other.value()), d.scale+other.scale)
+}
+
+// Div returns d / other, rounded half to even with DivisionScale more digits than the largest scale of both.
+// Trailing zeros are removed down to the largest scale. Example: 10.00 / 4 is 2.50.
+func (d Decimal) Div(other Decimal) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, adapters.ErrDivisionByZero
+	}
+
+	scale := max(d.scale, other.scale)
+	quotient := new(big.Rat).Quo(d.Rat(), other.Rat())
+
+	return roundRat(quotient, scale+DivisionScale, HalfEven).trim(scale), nil
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.value()), d.scale)
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	return newDecimal(new(big.Int).Abs(d.value()), d.scale)
+}
+
+// Pow returns d raised to the integer exponent, with the scale multiplied by the exponent.
+// Negative exponents divide 1 by the result, like Div. Results with more than MaxDigits digits overflow.
+func (d Decimal) Pow(exponent int64) (Decimal, error) {
+	// The negation of the minimum exponent overflows back to itself.
+	if exponent == math.MinInt64 {
+		return Decimal{}, fmt.Errorf("%s^%d: %w", d, exponent, adapters.ErrNumberOverflow)
+	}
+
+	if exponent < 0 {
+		power, err := d.Pow(-exponent)
+		if err != nil {
+			return Decimal{}, err
+		}
+
+		return FromInt(1).Div(power)
+	}
+
+	// The result has at least exponent times the bits of the unscaled value, minus one, and exponent times its scale.
+	bits := float64(max(d.value().BitLen()-1, 0)) * float64(exponent)
+	scale := float64(d.scale) * float64(exponent)
+
+	if bits > float64(MaxDigits)*math.Log2(10) || scale > float64(MaxDigits) {
+		return Decimal{}, fmt.Errorf("%s^%d: %w", d, exponent, adapters.ErrNumberOverflow)
+	}
+
+	return newDecimal(new(big.Int).Exp(d.value(), big.NewInt(exponent), nil), int32(scale)), nil
+}
+
+// Sqrt returns the square root of d, truncated to DivisionScale more digits than d.
+// Trailing zeros are removed down to the scale of d. Example: the square root of 6.25 is 2.50.
+func (d Decimal) Sqrt() (Decimal, error) {
+	if d.Sign() < 0 {
+		return Decimal{}, fmt.Errorf("square root of negative %s", d)
+	}
+
+	// The square root of unscaled * 10^(2*scale - d.scale) is the result scaled by 10^scale.
+	scale := d.scale + DivisionScale
+	radicand := new(big.Int).Mul(d.value(), pow10(2*scale-d.scale))
+
+	return newDecimal(radicand.Sqrt(radicand), scale).trim(d.scale), nil
+}
+
+// Cmp compares both decimals, returning -1, 0 or 1. Decimals are compared by value, so 1.50 equals 1.5.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.scale, other.scale)
+	return d.rescaled(scale).Cmp(other.rescaled(scale))
+}
+
+// Round returns d rounded to the given number of digits after the point, using the rounding mode.
+// The result always has the given scale. Example: 12.5 rounded to 2 places is 12.50.
+// Negative places round to tens, hundreds and so on.
+func (d Decimal) Round(places int32, mode RoundingMode) Decimal {
+	return roundRat(d.Rat(), places, mode)
+}
+
+// trim removes trailing zeros, down to the minimum scale.
+func (d Decimal) trim(minScale int32) Decimal {
+	unscaled := new(big.Int).Set(d.value())
+	scale := d.scale
+
+	remainder := new(big.Int)
+	for scale > minScale {
+		quotient, mod := new(big.Int).QuoRem(unscaled, ten, remainder)
+		if mod.Sign() != 0 {
+			break
+		}
+
+		unscaled = quotient
+		scale--
+	}
+
+	return newDecimal(unscaled, scale)
+}
+
+// Rat returns the decimal as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.value(), pow10(d.scale))
+}
+
+// Float64 returns the nearest float to the decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// String returns the decimal with all digits of its scale. Example: 12.50.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if padding := int(d.scale) + 1 - len(digits); padding > 0 {
+		digits = strings.Repeat("0", padding) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// roundRat rounds the rational number to the given number of digits after the point.
+func roundRat(r *big.Rat, places int32, mode RoundingMode) Decimal {
+	numerator := new(big.Int).Set(r.Num())
+	denominator := new(big.Int).Set(r.Denom())
+
+	if places >= 0 {
+		numerator.Mul(numerator, pow10(places))
+	} else {
+		denominator.Mul(denominator, pow10(-places))
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	if mode.roundsAway(quotient, remainder, denominator, numerator.Sign()) {
+		quotient.Add(quotient, big.NewInt(int64(numerator.Sign())))
+	}
+
+	return newDecimal(quotient, places)
+}
@@ -0,0 +1,159 @@
+package decimal_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Run("should keep the scale", func(t *testing.T) {
+		for _, input := range []string{"0", "12.50", "-0.05", "1000", "0.000"} {
+			got, err := decimal.Parse(input)
+			require.NoError(t, err, input)
+			assert.Equal(t, input, got.String())
+		}
+	})
+
+	t.Run("should normalize optional digits", func(t *testing.T) {
+		assert.Equal(t, "0.5", decimal.MustParse(".5").String())
+		assert.Equal(t, "12", decimal.MustParse("+12").String())
+	})
+
+	t.Run("should reject invalid decimals", func(t *testing.T) {
+		for _, input := range []string{"", "-", ".", "1.", "1e3", "--1", "1_000", "0x10", "1.2.3"} {
+			_, err := decimal.Parse(input)
+			assert.Error(t, err, input)
+		}
+	})
+
+	t.Run("should convert floats by their shortest representation", func(t *testing.T) {
+		got, err := decimal.FromFloat(0.1)
+		require.NoError(t, err)
+		assert.Equal(t, "0.1", got.String())
+	})
+}
+
+func Test_Decimal_Arithmetic(t *testing.T) {
+	t.Run("should add exactly", func(t *testing.T) {
+		got := decimal.MustParse("0.1").Add(decimal.MustParse("0.2"))
+		assert.Zero(t, got.Cmp(decimal.MustParse("0.3")))
+	})
+
+	t.Run("should keep the largest scale", func(t *testing.T) {
+		assert.Equal(t, "12.25", decimal.MustParse("12.50").Sub(decimal.MustParse("0.25")).String())
+		assert.Equal(t, "1.500", decimal.MustParse("0.5").Mul(decimal.MustParse("3.00")).String())
+	})
+
+	t.Run("should divide", func(t *testing.T) {
+		testCases := []struct {
+			first, second, expected string
+		}{
+			{"10.00", "4", "2.50"},
+			{"1", "3", "0.3333333333333333"},
+			{"-2", "3", "-0.6666666666666667"},
+			{"6", "2", "3"},
+		}
+
+		for _, tc := range testCases {
+			got, err := decimal.MustParse(tc.first).Div(decimal.MustParse(tc.second))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got.String(), "%s / %s", tc.first, tc.second)
+		}
+	})
+
+	t.Run("should not divide by zero", func(t *testing.T) {
+		_, err := decimal.FromInt(1).Div(decimal.Decimal{})
+		require.ErrorIs(t, err, adapters.ErrDivisionByZero)
+	})
+
+	t.Run("should raise to integer powers", func(t *testing.T) {
+		got, err := decimal.MustParse("1.5").Pow(2)
+		require.NoError(t, err)
+		assert.Equal(t, "2.25", got.String())
+
+		got, err = decimal.MustParse("2").Pow(-2)
+		require.NoError(t, err)
+		assert.Equal(t, "0.25", got.String())
+
+		_, err = decimal.Decimal{}.Pow(-1)
+		require.ErrorIs(t, err, adapters.ErrDivisionByZero)
+	})
+
+	t.Run("should not raise to unbounded powers", func(t *testing.T) {
+		_, err := decimal.FromInt(2).Pow(math.MinInt64)
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		_, err = decimal.MustParse("1.5").Pow(100000000)
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		_, err = decimal.FromInt(3).Pow(math.MaxInt64)
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		got, err := decimal.FromInt(-1).Pow(math.MaxInt64)
+		require.NoError(t, err)
+		assert.Equal(t, "-1", got.String())
+	})
+
+	t.Run("should take square roots", func(t *testing.T) {
+		got, err := decimal.MustParse("6.25").Sqrt()
+		require.NoError(t, err)
+		assert.Equal(t, "2.50", got.String())
+
+		got, err = decimal.FromInt(2).Sqrt()
+		require.NoError(t, err)
+		assert.Equal(t, "1.414213562373095", got.String())
+
+		_, err = decimal.FromInt(-1).Sqrt()
+		require.Error(t, err)
+	})
+
+	t.Run("should compare by value", func(t *testing.T) {
+		assert.Zero(t, decimal.MustParse("1.50").Cmp(decimal.MustParse("1.5")))
+		assert.Equal(t, -1, decimal.MustParse("-1").Cmp(decimal.Decimal{}))
+	})
+}
+
+func Test_Decimal_Round(t *testing.T) {
+	testCases := []struct {
+		mode     decimal.RoundingMode
+		inputs   []string
+		expected []string
+	}{
+		{decimal.HalfUp, []string{"2.5", "-2.5", "2.4", "1.25"}, []string{"3", "-3", "2", "1"}},
+		{decimal.HalfDown, []string{"2.5", "-2.5", "2.6", "1.25"}, []string{"2", "-2", "3", "1"}},
+		{decimal.HalfEven, []string{"2.5", "3.5", "-2.5", "2.51"}, []string{"2", "4", "-2", "3"}},
+		{decimal.Up, []string{"2.1", "-2.1", "2.0", "0"}, []string{"3", "-3", "2", "0"}},
+		{decimal.Down, []string{"2.9", "-2.9", "2.0", "0"}, []string{"2", "-2", "2", "0"}},
+		{decimal.Ceiling, []string{"2.1", "-2.9", "2.0", "0"}, []string{"3", "-2", "2", "0"}},
+		{decimal.Floor, []string{"2.9", "-2.1", "2.0", "0"}, []string{"2", "-3", "2", "0"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			for i, input := range tc.inputs {
+				got := decimal.MustParse(input).Round(0, tc.mode)
+				assert.Equal(t, tc.expected[i], got.String(), input)
+			}
+		})
+	}
+
+	t.Run("should set the scale", func(t *testing.T) {
+		assert.Equal(t, "12.50", decimal.MustParse("12.5").Round(2, decimal.HalfUp).String())
+		assert.Equal(t, "2.68", decimal.MustParse("2.675").Round(2, decimal.HalfUp).String())
+		assert.Equal(t, "1300", decimal.MustParse("1250").Round(-2, decimal.HalfUp).String())
+	})
+
+	t.Run("should parse rounding modes", func(t *testing.T) {
+		mode, err := decimal.ParseRoundingMode("halfEven")
+		require.NoError(t, err)
+		assert.Equal(t, decimal.HalfEven, mode)
+
+		_, err = decimal.ParseRoundingMode("nearest")
+		require.Error(t, err)
+	})
+}
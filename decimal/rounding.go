@@ -0,0 +1,85 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RoundingMode defines how discarded digits are rounded.
+// The zero value is HalfUp.
+type RoundingMode int
+
+const (
+	// HalfUp rounds to the nearest neighbour, and ties away from zero. Example: 2.5 is 3, and -2.5 is -3.
+	HalfUp RoundingMode = iota
+	// HalfDown rounds to the nearest neighbour, and ties towards zero. Example: 2.5 is 2.
+	HalfDown
+	// HalfEven rounds to the nearest neighbour, and ties to the even neighbour, also known as bankers' rounding. Example: 2.5 is 2, and 3.5 is 4.
+	HalfEven
+	// Up rounds away from zero. Example: 2.1 is 3, and -2.1 is -3.
+	Up
+	// Down rounds towards zero, truncating the discarded digits. Example: 2.9 is 2.
+	Down
+	// Ceiling rounds towards positive infinity. Example: 2.1 is 3, and -2.9 is -2.
+	Ceiling
+	// Floor rounds towards negative infinity. Example: 2.9 is 2, and -2.1 is -3.
+	Floor
+)
+
+var roundingModeNames = map[RoundingMode]string{
+	HalfUp:   "halfUp",
+	HalfDown: "halfDown",
+	HalfEven: "halfEven",
+	Up:       "up",
+	Down:     "down",
+	Ceiling:  "ceiling",
+	Floor:    "floor",
+}
+
+// ParseRoundingMode returns the rounding mode with the given name, like halfEven.
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	for mode, modeName := range roundingModeNames {
+		if modeName == name {
+			return mode, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown rounding mode '%s', expected one of halfUp, halfDown, halfEven, up, down, ceiling and floor", name)
+}
+
+func (mode RoundingMode) String() string {
+	if name, ok := roundingModeNames[mode]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("RoundingMode(%d)", int(mode))
+}
+
+// roundsAway reports whether the truncated quotient should be incremented away from zero, given the remainder of the division.
+func (mode RoundingMode) roundsAway(quotient, remainder, denominator *big.Int, sign int) bool {
+	if remainder.Sign() == 0 {
+		return false
+	}
+
+	// Compares the discarded fraction with a half.
+	half := new(big.Int).Abs(remainder)
+	half.Lsh(half, 1)
+	comparison := half.Cmp(denominator)
+
+	switch mode {
+	case HalfUp:
+		return comparison >= 0
+	case HalfDown:
+		return comparison > 0
+	case HalfEven:
+		return comparison > 0 || comparison == 0 && quotient.Bit(0) == 1
+	case Up:
+		return true
+	case Ceiling:
+		return sign > 0
+	case Floor:
+		return sign < 0
+	default:
+		return false
+	}
+}
@@ -3,6 +3,7 @@ package gon
 import (
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/sonalys/gon/adapters"
 	"github.com/sonalys/gon/internal/nodes"
@@ -13,24 +14,38 @@ type (
 	// The key must be an alphanumeric+underscore+dash string from length 1 to 50, starting with a letter.
 	Values map[string]adapters.Value
 
+	// definitionStore is a set of definitions, safe for concurrent use.
 	definitionStore struct {
+		mu    sync.RWMutex
 		store map[string]adapters.Value
 	}
 )
 
 var keyValidationRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]{1,50}$`)
 
-func newDefinitionResolver() adapters.DefinitionReadWriter {
+func newDefinitionStore() *definitionStore {
 	return &definitionStore{
 		store: make(map[string]adapters.Value),
 	}
 }
 
+// defines reports whether the top-level key is defined in the store.
+func (r *definitionStore) defines(topKey string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.store[topKey]
+	return ok
+}
+
 func (r *definitionStore) Definition(key string) (adapters.Value, bool) {
 	parts := strings.Split(key, ".")
 	topKey := parts[0]
 
+	r.mu.RLock()
 	value, ok := r.store[topKey]
+	r.mu.RUnlock()
+
 	if !ok {
 		return nodes.Literal(adapters.DefinitionNotFoundError{
 			DefinitionKey: topKey,
@@ -58,9 +73,11 @@ func (r *definitionStore) Define(key string, value adapters.Value) error {
 		}
 	}
 
+	r.mu.Lock()
 	r.store[key] = value
+	r.mu.Unlock()
 
 	return nil
 }
 
-var _ adapters.DefinitionReader = &definitionStore{}
+var _ adapters.DefinitionReadWriter = &definitionStore{}
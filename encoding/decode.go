@@ -4,13 +4,53 @@ import (
 	"fmt"
 
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
 )
 
+// Decode parses the buffer, and translates it to nodes using the codex.
+// Malformed input, and expressions not found in the codex, return a SyntaxError.
 func Decode(buffer []byte, codex Codex) (adapters.Node, error) {
-	tokens := tokenize(buffer)
-	parser := newParser(tokens)
+	tokens, err := tokenize(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	parser := newParser(buffer, tokens)
+
+	rootNode, err := parser.parse()
+	if err == nil {
+		err = parser.end()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	node, err := translateNode(rootNode, codex)
+	if err != nil {
+		return nil, fmt.Errorf("translating ast using codex: %w", err)
+	}
+
+	return node, nil
+}
+
+// DecodeInfix parses the buffer written in the infix syntax, and translates it to nodes using the codex.
+// It supports the operators == != < <= > >= && || ! + - * / and the ternary condition ? then : else,
+// with the usual precedence, parentheses, and the regular syntax for other nodes. Example: person.age >= 18 ? "pass" : "fail".
+// Operators are translated to the same nodes as the regular syntax, so a != b is decoded as not(equal(a, b)).
+// Comments are discarded. Malformed input, and expressions not found in the codex, return a SyntaxError.
+// References containing '-' can't be written, since it is parsed as subtraction: user-id is decoded as sub(user, id).
+func DecodeInfix(buffer []byte, codex Codex) (adapters.Node, error) {
+	tokens, err := tokenizeInfix(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	parser := newInfixParser(buffer, tokens)
 
 	rootNode, err := parser.parse()
+	if err == nil {
+		err = parser.end()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("parsing input: %w", err)
 	}
@@ -22,3 +62,65 @@ func Decode(buffer []byte, codex Codex) (adapters.Node, error) {
 
 	return node, nil
 }
+
+// DecodeWithComments decodes the buffer like Decode, also returning its comments.
+// Malformed input, and expressions not found in the codex, return a SyntaxError.
+// Each comment is attached to the nearest node: comments in the same line after a node are trailing,
+// and the others are leading comments of the following node.
+// The comments can be written back with the WithComments encoding option.
+func DecodeWithComments(buffer []byte, codex Codex) (adapters.Node, ast.CommentMap, error) {
+	tokens, comments, err := tokenizeWithComments(buffer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	parser := newCommentParser(buffer, tokens, comments)
+
+	rootNode, err := parser.parse()
+	if err == nil {
+		err = parser.end()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	// Comments after the root node are kept with it.
+	rootNode.Comments.Trailing = append(rootNode.Comments.Trailing, parser.leadingComments()...)
+
+	commentMap := make(ast.CommentMap)
+
+	node, err := translateNodeWithMetadata(rootNode, codex, commentMap, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("translating ast using codex: %w", err)
+	}
+
+	return node, commentMap, nil
+}
+
+// DecodeWithSpans decodes the buffer like Decode, also returning the source span of each node.
+// Use SpanMap.Locate for adding the span of the failed node to evaluation errors.
+func DecodeWithSpans(buffer []byte, codex Codex) (adapters.Node, adapters.SpanMap, error) {
+	tokens, err := tokenize(buffer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	parser := newParser(buffer, tokens)
+
+	rootNode, err := parser.parse()
+	if err == nil {
+		err = parser.end()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	spans := make(adapters.SpanMap)
+
+	node, err := translateNodeWithMetadata(rootNode, codex, nil, spans)
+	if err != nil {
+		return nil, nil, fmt.Errorf("translating ast using codex: %w", err)
+	}
+
+	return node, spans, nil
+}
@@ -0,0 +1,178 @@
+package encoding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeWithComments(t *testing.T) {
+	t.Run("should attach leading and trailing comments", func(t *testing.T) {
+		input := `// rule
+if(
+	// condition
+	condition: /* inner */ flag, // trailing
+	then: "yes"
+	// dangling
+) // end
+// footer`
+
+		node, comments, err := DecodeWithComments([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		shape := node.(adapters.SerializableNode).Shape()
+
+		require.Equal(t, ast.Comments{
+			Leading:  []string{"// rule"},
+			Trailing: []string{"// end", "// footer"},
+		}, comments[node])
+		require.Equal(t, ast.Comments{
+			Leading:  []string{"// condition", "/* inner */"},
+			Trailing: []string{"// trailing"},
+		}, comments[shape[0].Node])
+		require.Equal(t, ast.Comments{
+			Trailing: []string{"// dangling"},
+		}, comments[shape[1].Node])
+	})
+
+	t.Run("should move comments of replaced arguments to their parent", func(t *testing.T) {
+		node, comments, err := DecodeWithComments([]byte(`exists("key" /* c */)`), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		require.Equal(t, ast.Comments{Trailing: []string{"/* c */"}}, comments[node])
+		require.Len(t, comments, 1)
+	})
+
+	t.Run("should decode the same tree as Decode", func(t *testing.T) {
+		input := "// c\nequal(a, /* b */ 1)"
+
+		expected, err := Decode([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		node, _, err := DecodeWithComments([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		require.Equal(t, expected, node)
+	})
+}
+
+func Test_Decode_Numbers(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected any
+	}{
+		{input: `42`, expected: int64(42)},
+		{input: `-42`, expected: int64(-42)},
+		{input: `1_000_000`, expected: int64(1000000)},
+		{input: `0xFF`, expected: int64(255)},
+		{input: `0o17`, expected: int64(15)},
+		{input: `0b101`, expected: int64(5)},
+		{input: `1.5`, expected: 1.5},
+		{input: `.5`, expected: 0.5},
+		{input: `1e6`, expected: 1e6},
+		{input: `-1.5e-3`, expected: -1.5e-3},
+		{input: `0x1p-2`, expected: 0.25},
+		{input: `0x1d`, expected: int64(29)},
+		{input: `12.50d`, expected: decimal.MustParse("12.50")},
+		{input: `-0.1d`, expected: decimal.MustParse("-0.1")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			node, err := Decode([]byte(tc.input), DefaultExpressionCodex)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, node.(adapters.Valued).Value())
+
+			node, err = DecodeInfix([]byte(tc.input), DefaultExpressionCodex)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, node.(adapters.Valued).Value())
+		})
+	}
+
+	t.Run("should not treat malformed numbers as references", func(t *testing.T) {
+		for _, input := range []string{`1a`, `0xZZ`, `08`, `1e`, `1__0`, `1.d`, `1e3d`} {
+			_, err := Decode([]byte(input), DefaultExpressionCodex)
+			require.ErrorAs(t, err, &SyntaxError{}, input)
+		}
+	})
+}
+
+func Test_Decode_Null(t *testing.T) {
+	t.Run("should decode null as a nil literal", func(t *testing.T) {
+		node, err := Decode([]byte(`null`), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		got, err := ast.Parse(node)
+		require.NoError(t, err)
+		require.Equal(t, ast.Literal{Value: nil}, got)
+	})
+
+	t.Run("should round-trip null", func(t *testing.T) {
+		for _, input := range []string{`null`, `isNull(null)`} {
+			node, err := Decode([]byte(input), DefaultExpressionCodex)
+			require.NoError(t, err, input)
+			require.Equal(t, input, compact(t, node))
+		}
+
+		node, err := DecodeInfix([]byte(`a == null`), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, HumanEncode(buf, node, Compact(), Infix()))
+		require.Equal(t, `a == null`, buf.String())
+	})
+
+	t.Run("should encode nil pointers as null", func(t *testing.T) {
+		require.Equal(t, `null`, compact(t, nodes.Literal((*int)(nil))))
+	})
+
+	t.Run("should not name parameters null", func(t *testing.T) {
+		_, err := Decode([]byte(`equal(null: 1, 2)`), DefaultExpressionCodex)
+		require.Error(t, err)
+	})
+}
+
+func Test_Decode_SyntaxError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		position Position
+		message  string
+	}{
+		{name: "should report empty input", input: ``, position: Position{Offset: 0, Line: 1, Column: 1}, message: "unexpected end of input"},
+		{name: "should report missing parenthesis", input: "if(\n\tequal(a, b),\n\ttrue", position: Position{Offset: 0, Line: 1, Column: 1}, message: "missing ')' for 'if'"},
+		{name: "should report unexpected tokens", input: "not(\n\ta: )", position: Position{Offset: 9, Line: 2, Column: 5}, message: "unexpected ')'"},
+		{name: "should report repeated keys", input: "not(a: b: c)", position: Position{Offset: 8, Line: 1, Column: 9}, message: "unexpected ':'"},
+		{name: "should report repeated cases", input: `switch(a, 1: 2: 3)`, position: Position{Offset: 14, Line: 1, Column: 15}, message: "unexpected ':'"},
+		{name: "should report unterminated strings", input: `not("abc)`, position: Position{Offset: 4, Line: 1, Column: 5}, message: "unterminated string"},
+		{name: "should report unterminated comments", input: "not(a) /* open\n", position: Position{Offset: 7, Line: 1, Column: 8}, message: "unterminated comment"},
+		{name: "should report tokens after expression", input: "not(a)\nb", position: Position{Offset: 7, Line: 2, Column: 1}, message: "unexpected 'b' after expression"},
+		{name: "should report unknown expressions", input: "not(\n\tunknown(1))", position: Position{Offset: 6, Line: 2, Column: 2}, message: "codex for 'unknown' not found"},
+		{name: "should report constructor errors", input: `not(time("bad"))`, position: Position{Offset: 4, Line: 1, Column: 5}, message: "building 'time'"},
+		{name: "should report malformed numbers", input: `sum(1, 1.2.3)`, position: Position{Offset: 7, Line: 1, Column: 8}, message: "invalid number '1.2.3': invalid syntax"},
+		{name: "should report out of range numbers", input: `sum(99999999999999999999)`, position: Position{Offset: 4, Line: 1, Column: 5}, message: "value out of range"},
+		{name: "should count columns in runes", input: `equal("çã", ?(`, position: Position{Offset: 14, Line: 1, Column: 13}, message: "missing ')' for '?'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, decode := range []func([]byte, Codex) error{
+				func(b []byte, c Codex) error { _, err := Decode(b, c); return err },
+				func(b []byte, c Codex) error { _, _, err := DecodeWithComments(b, c); return err },
+				func(b []byte, c Codex) error { _, _, err := DecodeWithSpans(b, c); return err },
+			} {
+				err := decode([]byte(tc.input), DefaultExpressionCodex)
+
+				var syntaxErr SyntaxError
+				require.ErrorAs(t, err, &syntaxErr)
+				require.Equal(t, tc.position, syntaxErr.Position)
+				require.ErrorContains(t, syntaxErr, tc.message)
+			}
+		})
+	}
+}
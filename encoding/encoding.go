@@ -2,6 +2,7 @@ package encoding
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/sonalys/gon/adapters"
 	"github.com/sonalys/gon/internal/nodes"
@@ -37,24 +38,91 @@ func (c *Codex) AutoRegister(nodes ...adapters.AutoRegisterer) error {
 	return nil
 }
 
+// RegisterLiteral registers a codec for literals of type T, encoded as a named expression with a string argument.
+// The constructor is added to DefaultExpressionCodex, so it should be called before cloning it, like in an init function.
+// Example: RegisterLiteral("uuid", uuid.UUID.String, uuid.Parse) encodes uuids as uuid("...").
+func RegisterLiteral[T any](name string, encode func(T) string, decode func(string) (T, error)) error {
+	typeOf := reflect.TypeFor[T]()
+	if typeOf.Kind() == reflect.Interface {
+		return fmt.Errorf("literal codec '%s' should be registered for a concrete type, got %s", name, typeOf)
+	}
+
+	if _, conflicts := DefaultExpressionCodex[name]; conflicts {
+		return fmt.Errorf("node with name '%s' is already registered", name)
+	}
+
+	codec := nodes.LiteralCodec{
+		Name: name,
+		Encode: func(value any) string {
+			return encode(value.(T))
+		},
+		Decode: func(raw string) (any, error) {
+			value, err := decode(raw)
+			if err != nil {
+				return nil, err
+			}
+
+			return value, nil
+		},
+	}
+
+	if err := nodes.RegisterLiteralCodec(typeOf, codec); err != nil {
+		return err
+	}
+
+	return DefaultExpressionCodex.Register(name, codec.Constructor())
+}
+
 func init() {
 	err := DefaultExpressionCodex.AutoRegister(
+		&nodes.AbsNode{},
+		&nodes.AggregateNode{},
+		&nodes.AndNode{},
 		&nodes.AvgNode{},
 		&nodes.CallNode{},
+		&nodes.CeilNode{},
+		&nodes.ClampNode{},
 		&nodes.CoalesceNode{},
+		&nodes.CondNode{},
+		&nodes.DistinctNode{},
+		&nodes.DivNode{},
 		&nodes.EqualNode{},
+		&nodes.ErrorNode{},
 		&nodes.ExistsNode{},
+		&nodes.FirstNode{},
+		&nodes.FlattenNode{},
+		&nodes.FloorNode{},
 		&nodes.GreaterNode{},
+		&nodes.GroupByNode{},
 		&nodes.HasPrefixNode{},
 		&nodes.HasSuffixNode{},
 		&nodes.IfNode{},
+		&nodes.ImpliesNode{},
+		&nodes.InNode{},
 		&nodes.IsEmptyNode{},
+		&nodes.IsErrorNode{},
+		&nodes.IsNullNode{},
 		&nodes.LiteralNode{},
+		&nodes.MaxNode{},
+		&nodes.MinNode{},
+		&nodes.MulNode{},
 		&nodes.NotNode{},
 		&nodes.OrNode{},
+		&nodes.PercentileOfNode{},
+		&nodes.PowNode{},
 		&nodes.ReferenceNode{},
+		&nodes.RoundNode{},
+		&nodes.SetNode{},
+		&nodes.SliceNode{},
 		&nodes.SmallerNode{},
+		&nodes.SortNode{},
+		&nodes.SqrtNode{},
+		&nodes.SubNode{},
 		&nodes.SumNode{},
+		&nodes.SwitchNode{},
+		&nodes.ThresholdNode{},
+		&nodes.TryNode{},
+		&nodes.XorNode{},
 	)
 	if err != nil {
 		panic(fmt.Errorf("unexpected error registering default nodes: %s", err))
@@ -0,0 +1,64 @@
+package encoding
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/require"
+)
+
+type money struct {
+	cents    int64
+	currency string
+}
+
+func Test_RegisterLiteral(t *testing.T) {
+	err := RegisterLiteral("money",
+		func(value money) string {
+			return fmt.Sprintf("%d %s", value.cents, value.currency)
+		},
+		func(raw string) (money, error) {
+			var value money
+			_, err := fmt.Sscanf(raw, "%d %s", &value.cents, &value.currency)
+			return value, err
+		},
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		nodes.UnregisterLiteralCodec(reflect.TypeFor[money]())
+		delete(DefaultExpressionCodex, "money")
+	})
+
+	t.Run("should round-trip the literal", func(t *testing.T) {
+		node := nodes.Literal(money{cents: 1250, currency: "EUR"})
+		require.Equal(t, `money("1250 EUR")`, compact(t, node))
+
+		got, err := Decode([]byte(`money("1250 EUR")`), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, money{cents: 1250, currency: "EUR"}, got.Eval(nil).Value())
+	})
+
+	t.Run("should report decoding errors", func(t *testing.T) {
+		_, err := Decode([]byte(`money("EUR")`), DefaultExpressionCodex)
+		require.ErrorAs(t, err, &SyntaxError{})
+	})
+
+	t.Run("should not register conflicting codecs", func(t *testing.T) {
+		err := RegisterLiteral("money", func(value int) string { return "" }, func(raw string) (int, error) { return 0, nil })
+		require.Error(t, err)
+
+		err = RegisterLiteral("cash", func(value money) string { return "" }, func(raw string) (money, error) { return money{}, nil })
+		require.Error(t, err)
+
+		err = RegisterLiteral("sum", func(value uint8) string { return "" }, func(raw string) (uint8, error) { return 0, nil })
+		require.Error(t, err)
+	})
+
+	t.Run("should not register interfaces", func(t *testing.T) {
+		err := RegisterLiteral("stringer", fmt.Stringer.String, func(raw string) (fmt.Stringer, error) { return nil, nil })
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,47 @@
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Position is a location in the decoded input.
+type Position struct {
+	// Offset is the byte offset, starting at 0.
+	Offset int
+	// Line is the line number, starting at 1.
+	Line int
+	// Column is the rune offset in the line, starting at 1.
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// SyntaxError is returned when decoding malformed input, or expressions not found in the codex.
+type SyntaxError struct {
+	Position Position
+	Cause    error
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Cause)
+}
+
+func (e SyntaxError) Unwrap() error {
+	return e.Cause
+}
+
+// positionOf returns the position of the byte offset in the input.
+func positionOf(input []byte, offset int) Position {
+	offset = min(offset, len(input))
+	lineStart := bytes.LastIndexByte(input[:offset], '\n') + 1
+
+	return Position{
+		Offset: offset,
+		Line:   bytes.Count(input[:offset], []byte("\n")) + 1,
+		Column: utf8.RuneCount(input[lineStart:offset]) + 1,
+	}
+}
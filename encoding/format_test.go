@@ -0,0 +1,222 @@
+package encoding
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Format(t *testing.T) {
+	t.Run("should produce the same layout for equivalent inputs", func(t *testing.T) {
+		inputs := []string{
+			`if(gte(person.age, 18), "pass", "fail")`,
+			`if(condition: gte(first: person.age, second: 18), then: "pass", else: "fail")`,
+			"if(\n\tgte(\n\t\tperson.age,\n\t\t18\n\t),\n\t\"pass\",\n\t\"fail\"\n)",
+			`if(else: "fail", then: "pass", condition: gte(person.age, 18))`,
+		}
+
+		expected := "if(condition: gte(first: person.age, second: 18), then: \"pass\", else: \"fail\")\n"
+
+		for _, input := range inputs {
+			got, err := Format([]byte(input), DefaultExpressionCodex)
+			require.NoError(t, err)
+			require.Equal(t, expected, string(got), input)
+		}
+	})
+
+	t.Run("should be idempotent", func(t *testing.T) {
+		input := `if(or(equal(file.uid, 1023), equal(file.gid, 102), hasPrefix(file.path, "/shared")), call("allow", file.path, "read"), call("deny", file.path))`
+
+		first, err := Format([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		second, err := Format(first, DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		require.Equal(t, string(first), string(second))
+	})
+
+	t.Run("should break expressions longer than the line width", func(t *testing.T) {
+		input := `if(or(equal(file.uid, 1023), equal(file.gid, 102), hasPrefix(file.path, "/shared")), true, false)`
+
+		expected := `if(
+	condition: or(
+		equal(first: file.uid, second: 1023),
+		equal(first: file.gid, second: 102),
+		hasPrefix(text: file.path, prefix: "/shared")
+	),
+	then: true,
+	else: false
+)
+`
+
+		got, err := Format([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(got))
+	})
+
+	t.Run("should break single argument expressions", func(t *testing.T) {
+		input := `not(hasPrefix(file.path, "/a/very/long/path/that/does/not/fit/in/a/single/line/"))`
+
+		expected := `not(
+	expression: hasPrefix(
+		text: file.path,
+		prefix: "/a/very/long/path/that/does/not/fit/in/a/single/line/"
+	)
+)
+`
+
+		got, err := Format([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(got))
+	})
+
+	t.Run("should respect custom line width", func(t *testing.T) {
+		input := `equal(first: file.uid, second: 1023)`
+
+		expected := "equal(\n\tfile.uid,\n\t1023\n)\n"
+
+		got, err := Format([]byte(input), DefaultExpressionCodex, LineWidth(20), Unnamed())
+		require.NoError(t, err)
+		require.Equal(t, expected, string(got))
+	})
+
+	t.Run("should account for separators in line width", func(t *testing.T) {
+		// 'sum(1, 2)' fits in 9 columns, but not with the following comma.
+		input := `or(sum(1, 2), x)`
+
+		expected := "or(\n\tsum(\n\t\t1,\n\t\t2\n\t),\n\tx\n)\n"
+
+		got, err := Format([]byte(input), DefaultExpressionCodex, LineWidth(4+9))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(got))
+	})
+
+	t.Run("should preserve comments", func(t *testing.T) {
+		input := `// Adults only.
+if(
+	// is adult?
+	condition: gte(person.age, 18), // inclusive
+	then: "pass" /* ok */,
+) // end`
+
+		expected := `// Adults only.
+if(
+	// is adult?
+	condition: gte(first: person.age, second: 18), // inclusive
+	then: "pass" /* ok */
+) // end
+`
+
+		got, err := Format([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(got))
+
+		again, err := Format(got, DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(again))
+	})
+
+	t.Run("should keep short expressions with comments in a single line", func(t *testing.T) {
+		got, err := Format([]byte("// flag\nnot(flag) // negated"), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, "// flag\nnot(expression: flag) // negated\n", string(got))
+	})
+
+	t.Run("should write block comments in compact mode", func(t *testing.T) {
+		got, err := Format([]byte("not(\n\t// negated */\n\tflag,\n)"), DefaultExpressionCodex, Compact())
+		require.NoError(t, err)
+		require.Equal(t, "not(/* negated * / */ expression: flag)\n", string(got))
+	})
+
+	t.Run("should write cases before their values", func(t *testing.T) {
+		input := `cond(gt(amount, 100): "high", gt(amount, 10): "mid", "low")`
+
+		got, err := Format([]byte(input), DefaultExpressionCodex, LineWidth(40))
+		require.NoError(t, err)
+		require.Equal(t, "cond(\n\tgt(first: amount, second: 100): \"high\",\n\tgt(first: amount, second: 10): \"mid\",\n\tdefault: \"low\"\n)\n", string(got))
+
+		got, err = Format(got, DefaultExpressionCodex, Compact(), Unnamed())
+		require.NoError(t, err)
+		require.Equal(t, "cond(gt(amount,100): \"high\",gt(amount,10): \"mid\",\"low\")\n", string(got))
+	})
+
+	t.Run("should keep comments of cases with their values", func(t *testing.T) {
+		input := "switch(\n\ttier,\n\t// best tier\n\t\"gold\": 1,\n)"
+
+		got, err := Format([]byte(input), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, "switch(\n\tvalue: tier,\n\t// best tier\n\t\"gold\": 1\n)\n", string(got))
+	})
+
+	t.Run("should propagate decoding errors", func(t *testing.T) {
+		_, err := Format([]byte(`unknown(1)`), DefaultExpressionCodex)
+		require.Error(t, err)
+	})
+}
+
+func Test_HumanEncode(t *testing.T) {
+	t.Run("compact should ignore line width", func(t *testing.T) {
+		node := nodes.If(nodes.Equal(nodes.Reference("file.uid"), nodes.Literal(1023)), nodes.Literal(true))
+
+		buf := bytes.NewBuffer(nil)
+		err := HumanEncode(buf, node, Compact(), LineWidth(1))
+		require.NoError(t, err)
+		require.Equal(t, `if(condition: equal(first: file.uid,second: 1023),then: true)`, buf.String())
+	})
+
+	t.Run("should encode expressions without arguments", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := HumanEncode(buf, nodes.Call("now"))
+		require.NoError(t, err)
+		require.Equal(t, `call("now")`, buf.String())
+	})
+
+	t.Run("should encode floats that decode as floats", func(t *testing.T) {
+		for _, value := range []float64{2, -2, 0.5, 1e21, 1.5e-7} {
+			buf := bytes.NewBuffer(nil)
+			require.NoError(t, HumanEncode(buf, nodes.Literal(value)))
+
+			node, err := Decode(buf.Bytes(), DefaultExpressionCodex)
+			require.NoError(t, err)
+			require.Equal(t, value, node.(adapters.Valued).Value(), buf.String())
+		}
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, HumanEncode(buf, nodes.Literal(2.0)))
+		require.Equal(t, "2.0", buf.String())
+	})
+
+	t.Run("should encode special floats that decode as floats", func(t *testing.T) {
+		for _, value := range []float64{math.Inf(1), math.Inf(-1), math.NaN()} {
+			for _, decode := range []func([]byte, Codex) (adapters.Node, error){Decode, DecodeInfix} {
+				buf := bytes.NewBuffer(nil)
+				require.NoError(t, HumanEncode(buf, nodes.Literal(value)))
+
+				node, err := decode(buf.Bytes(), DefaultExpressionCodex)
+				require.NoError(t, err, buf.String())
+
+				got, ok := node.(adapters.Valued).Value().(float64)
+				require.True(t, ok, buf.String())
+				require.True(t, got == value || math.IsNaN(got) && math.IsNaN(value), buf.String())
+			}
+		}
+
+		node, err := Decode([]byte(`+Inf`), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, math.Inf(1), node.(adapters.Valued).Value())
+
+		node, err = DecodeInfix([]byte(`x > -Inf`), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, "gt(first: x,second: -Inf)", compact(t, node))
+	})
+
+	t.Run("should error on invalid nodes", func(t *testing.T) {
+		err := HumanEncode(bytes.NewBuffer(nil), adapters.NodeError{})
+		require.Error(t, err)
+	})
+}
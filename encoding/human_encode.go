@@ -1,104 +1,367 @@
 package encoding
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/sonalys/gon/adapters"
 	"github.com/sonalys/gon/ast"
 )
 
+const (
+	// DefaultLineWidth is the default maximum line width for HumanEncode.
+	DefaultLineWidth = 80
+	// tabWidth is the width of an indentation tab, used for measuring lines.
+	tabWidth = 4
+)
+
 // HumanEncode encodes the node in a human-friendly format.
+// An expression is written in a single line if it fits the line width,
+// otherwise each of its arguments is written in its own line, indented by one tab.
+// The output is deterministic, so the same node always produces the same text.
 func HumanEncode(w io.Writer, root adapters.Node, opts ...HumanEncodeOption) error {
 	cfg := &humanEncodeConfig{
 		showParamName: true,
+		lineWidth:     DefaultLineWidth,
 	}
 
 	for _, opt := range opts {
 		opt.applyHumanEncodeOption(cfg)
 	}
 
-	astNode, err := ast.Parse(root)
+	astNode, err := ast.ParseWithComments(root, cfg.comments)
 	if err != nil {
 		return fmt.Errorf("encoding root expression: %w", err)
 	}
 
-	return encodeBody(w, astNode, 0, cfg)
+	p := &printer{
+		cfg: cfg,
+		buf: bytes.NewBuffer(nil),
+	}
+
+	comments := commentsOf(astNode)
+
+	if p.cfg.infix {
+		infix, _, err := p.infix(astNode)
+		if err != nil {
+			return err
+		}
+
+		p.buf.WriteString(infix)
+	} else if p.cfg.compact {
+		if err := p.encodeCompact(astNode, ""); err != nil {
+			return err
+		}
+	} else {
+		p.leading(comments, 0)
+
+		if err := p.encode(astNode, 0, 0, 0); err != nil {
+			return err
+		}
+
+		p.trailing(comments, 0)
+	}
+
+	_, err = w.Write(p.buf.Bytes())
+	return err
+}
+
+// Format decodes the source and encodes it back with HumanEncode, ending with a new line.
+// It can be used for keeping rules in a canonical format, minimizing diffs between versions.
+// Comments are preserved.
+func Format(src []byte, codex Codex, opts ...HumanEncodeOption) ([]byte, error) {
+	node, comments, err := DecodeWithComments(src, codex)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(src)))
+
+	opts = append([]HumanEncodeOption{WithComments(comments)}, opts...)
+
+	if err := HumanEncode(buf, node, opts...); err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
 }
 
 type humanEncodeConfig struct {
 	compact       bool
+	infix         bool
 	showParamName bool
+	lineWidth     int
+	comments      ast.CommentMap
 }
 
 type HumanEncodeOption interface {
 	applyHumanEncodeOption(*humanEncodeConfig)
 }
 
-func encodeBody(w io.Writer, root ast.AstNode, indentation int, cfg *humanEncodeConfig) error {
-	print := func(indentation int, mask string, args ...any) {
-		if cfg.compact {
-			indentation = 0
+type printer struct {
+	cfg *humanEncodeConfig
+	buf *bytes.Buffer
+}
+
+// encode writes the node, starting at the given column.
+// The trailing length is reserved after the node, for the argument separator.
+func (p *printer) encode(root ast.AstNode, indentation, column, trailing int) error {
+	flat, err := p.flat(root)
+	if err != nil {
+		return err
+	}
+
+	node, isExpression := root.(ast.Expression)
+	if !isExpression || !hasInnerComments(node) && column+utf8.RuneCountInString(flat)+trailing <= p.cfg.lineWidth {
+		p.buf.WriteString(flat)
+		return nil
+	}
+
+	p.buf.WriteString(node.Scalar)
+	p.buf.WriteString("(\n")
+
+	for i, arg := range node.KeyArgs {
+		isLast := i == len(node.KeyArgs)-1
+		comments := commentsOf(arg.Node)
+
+		p.leading(comments, indentation+1)
+		p.indent(indentation + 1)
+
+		key, err := p.key(node.KeyArgs, i)
+		if err != nil {
+			return err
 		}
 
-		if indentation > 0 {
-			_, _ = fmt.Fprint(w, strings.Repeat("\t", indentation))
+		p.buf.WriteString(key)
 
+		argTrailing := 1
+		if isLast {
+			argTrailing = 0
 		}
 
-		_, _ = fmt.Fprintf(w, mask, args...)
-	}
+		argColumn := (indentation+1)*tabWidth + utf8.RuneCountInString(key)
+		if err := p.encode(arg.Node, indentation+1, argColumn, argTrailing); err != nil {
+			return err
+		}
 
-	endLine := func() {
-		if cfg.compact {
-			return
+		if !isLast {
+			p.buf.WriteByte(',')
 		}
 
-		print(0, "\n")
+		p.trailing(comments, indentation+1)
+		p.buf.WriteByte('\n')
 	}
 
-	switch node := root.(type) {
-	case ast.Expression:
-		print(0, "%s(", node.Scalar)
+	p.indent(indentation)
+	p.buf.WriteByte(')')
+
+	return nil
+}
+
+// encodeCompact writes the node in a single line, with its comments as block comments.
+func (p *printer) encodeCompact(root ast.AstNode, key string) error {
+	comments := commentsOf(root)
+
+	for _, comment := range comments.Leading {
+		p.buf.WriteString(blockComment(comment))
+		p.buf.WriteByte(' ')
+	}
+
+	p.buf.WriteString(key)
+
+	if node, ok := root.(ast.Expression); ok {
+		p.buf.WriteString(node.Scalar)
+		p.buf.WriteByte('(')
 
 		for i, arg := range node.KeyArgs {
-			if i > 0 || len(node.KeyArgs) != 1 || (i == 0 && len(node.KeyArgs) > 1 && arg.Key != "" && node.KeyArgs[i+1].Key != "") {
-				endLine()
-				print(indentation+1, "")
-			}
-			if cfg.showParamName && arg.Key != "" {
-				print(0, "%s: ", arg.Key)
+			if i > 0 {
+				p.buf.WriteByte(',')
 			}
 
-			if err := encodeBody(w, arg.Node, indentation+1, cfg); err != nil {
+			key, err := p.key(node.KeyArgs, i)
+			if err != nil {
 				return err
 			}
 
-			if i < len(node.KeyArgs)-1 {
-				print(0, ",")
+			if err := p.encodeCompact(arg.Node, key); err != nil {
+				return err
 			}
 		}
 
-		if len(node.KeyArgs) > 1 {
-			endLine()
-			print(indentation, ")")
-			break
+		p.buf.WriteByte(')')
+	} else {
+		flat, err := p.flat(root)
+		if err != nil {
+			return err
 		}
 
-		print(0, ")")
+		p.buf.WriteString(flat)
+	}
+
+	for _, comment := range comments.Trailing {
+		p.buf.WriteByte(' ')
+		p.buf.WriteString(blockComment(comment))
+	}
+
+	return nil
+}
+
+// flat returns the node encoded in a single line, without comments.
+func (p *printer) flat(root ast.AstNode) (string, error) {
+	switch node := root.(type) {
+	case ast.Expression:
+		separator := ", "
+		if p.cfg.compact {
+			separator = ","
+		}
+
+		args := make([]string, 0, len(node.KeyArgs))
+		for i, arg := range node.KeyArgs {
+			key, err := p.key(node.KeyArgs, i)
+			if err != nil {
+				return "", err
+			}
+
+			value, err := p.flat(arg.Node)
+			if err != nil {
+				return "", err
+			}
+
+			args = append(args, key+value)
+		}
+
+		return node.Scalar + "(" + strings.Join(args, separator) + ")", nil
 	case ast.Reference:
-		print(0, "%v", node.Name)
+		return node.Name, nil
 	case ast.Literal:
-		value := node.Value
-		if str, ok := value.(string); ok {
-			value = strconv.Quote(str)
+		return formatLiteral(node.Value), nil
+	default:
+		return "", errors.New("cannot encode invalid expression type")
+	}
+}
+
+// key returns the prefix of the argument value.
+// Cases are always written, since they are part of the argument.
+// Names after an unnamed argument, like call keywords, are also always written, since they can't be matched by position.
+func (p *printer) key(args []ast.KeyNode, i int) (string, error) {
+	arg := args[i]
+
+	if arg.Case != nil {
+		flat, err := p.flat(arg.Case)
+		if err != nil {
+			return "", err
+		}
+
+		return flat + ": ", nil
+	}
+
+	if arg.Key == "" {
+		return "", nil
+	}
+
+	if !p.cfg.showParamName && !slices.ContainsFunc(args[:i], isPositional) {
+		return "", nil
+	}
+
+	return arg.Key + ": ", nil
+}
+
+func isPositional(arg ast.KeyNode) bool {
+	return arg.Key == "" && arg.Case == nil
+}
+
+func (p *printer) indent(indentation int) {
+	p.buf.WriteString(strings.Repeat("\t", indentation))
+}
+
+// leading writes each leading comment in its own line.
+func (p *printer) leading(comments ast.Comments, indentation int) {
+	for _, comment := range comments.Leading {
+		p.indent(indentation)
+		p.buf.WriteString(comment)
+		p.buf.WriteByte('\n')
+	}
+}
+
+// trailing writes the first trailing comment in the current line, and the following ones in their own lines.
+func (p *printer) trailing(comments ast.Comments, indentation int) {
+	for i, comment := range comments.Trailing {
+		if i == 0 {
+			p.buf.WriteByte(' ')
+		} else {
+			p.buf.WriteByte('\n')
+			p.indent(indentation)
 		}
-		print(0, "%v", value)
+
+		p.buf.WriteString(comment)
+	}
+}
+
+func commentsOf(node ast.AstNode) ast.Comments {
+	switch node := node.(type) {
+	case ast.Expression:
+		return node.Comments
+	case ast.Literal:
+		return node.Comments
+	case ast.Reference:
+		return node.Comments
 	default:
-		return errors.New("cannot encode invalid expression type")
+		return ast.Comments{}
 	}
-	return nil
+}
+
+// hasInnerComments reports whether any argument of the expression has comments, at any depth.
+func hasInnerComments(node ast.Expression) bool {
+	for _, arg := range node.KeyArgs {
+		if !commentsOf(arg.Node).IsEmpty() {
+			return true
+		}
+
+		if expression, ok := arg.Node.(ast.Expression); ok && hasInnerComments(expression) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// blockComment converts line comments to block comments, so they can be followed by other tokens.
+func blockComment(comment string) string {
+	text, isLineComment := strings.CutPrefix(comment, string(lineCommentStart))
+	if !isLineComment {
+		return comment
+	}
+
+	text = strings.ReplaceAll(strings.TrimSpace(text), string(blockCommentEnd), "* /")
+
+	return string(blockCommentStart) + " " + text + " " + string(blockCommentEnd)
+}
+
+func formatLiteral(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(value)
+	case float64:
+		return formatFloat(value, 64)
+	case float32:
+		return formatFloat(float64(value), 32)
+	}
+
+	// Nil pointers, like unset struct fields, are written as null.
+	if valueOf := reflect.ValueOf(value); valueOf.Kind() == reflect.Pointer && valueOf.IsNil() {
+		return "null"
+	}
+
+	return fmt.Sprintf("%v", value)
 }
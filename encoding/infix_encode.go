@@ -0,0 +1,188 @@
+package encoding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sonalys/gon/ast"
+)
+
+// Precedences of the infix operators, from the lowest to the highest.
+const (
+	precedenceTernary = iota + 1
+	precedenceOr
+	precedenceAnd
+	precedenceEquality
+	precedenceComparison
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceUnary
+	precedencePrimary
+)
+
+// infixOperator is the infix form of a node.
+type infixOperator struct {
+	symbol     string
+	precedence int
+	variadic   bool
+}
+
+// infixOperators are the nodes written as infix operators by the Infix option.
+var infixOperators = map[string]infixOperator{
+	"or":    {symbol: "||", precedence: precedenceOr, variadic: true},
+	"and":   {symbol: "&&", precedence: precedenceAnd, variadic: true},
+	"equal": {symbol: "==", precedence: precedenceEquality},
+	"lt":    {symbol: "<", precedence: precedenceComparison},
+	"lte":   {symbol: "<=", precedence: precedenceComparison},
+	"gt":    {symbol: ">", precedence: precedenceComparison},
+	"gte":   {symbol: ">=", precedence: precedenceComparison},
+	"sum":   {symbol: "+", precedence: precedenceAdditive, variadic: true},
+	"sub":   {symbol: "-", precedence: precedenceAdditive},
+	"mul":   {symbol: "*", precedence: precedenceMultiplicative, variadic: true},
+	"div":   {symbol: "/", precedence: precedenceMultiplicative},
+}
+
+// infix returns the node in the infix syntax, with the precedence of its outermost operator.
+// Nodes without an infix form are written as function calls, with their arguments in the infix syntax.
+func (p *printer) infix(root ast.AstNode) (string, int, error) {
+	// The infix syntax has no escape for '-' in references, which is parsed as a subtraction.
+	if reference, ok := root.(ast.Reference); ok && strings.Contains(reference.Name, "-") {
+		return "", 0, fmt.Errorf("reference '%s' cannot be written in the infix syntax, since '-' is the subtraction operator", reference.Name)
+	}
+
+	node, ok := root.(ast.Expression)
+	if !ok {
+		flat, err := p.flat(root)
+		return flat, precedencePrimary, err
+	}
+
+	switch {
+	case node.Scalar == "if" && len(node.KeyArgs) == 3 && !hasCases(node):
+		return p.infixTernary(node)
+	case node.Scalar == "not" && len(node.KeyArgs) == 1 && !hasCases(node):
+		return p.infixNot(node)
+	}
+
+	operator, ok := infixOperators[node.Scalar]
+	if !ok || hasCases(node) || len(node.KeyArgs) < 2 || !operator.variadic && len(node.KeyArgs) != 2 {
+		return p.infixCall(node)
+	}
+
+	operands := make([]string, 0, len(node.KeyArgs))
+
+	for i, arg := range node.KeyArgs {
+		operand, precedence, err := p.infix(arg.Node)
+		if err != nil {
+			return "", 0, err
+		}
+
+		// Operators are left associative, so only the first operand can have the same precedence without parentheses.
+		// Comparisons can't be chained, and nested variadic nodes are kept apart from their parent.
+		grouped := precedence < operator.precedence ||
+			precedence == operator.precedence && (i > 0 || operator.precedence == precedenceEquality ||
+				operator.precedence == precedenceComparison || operator.variadic && isExpression(arg.Node, node.Scalar))
+
+		operands = append(operands, parenthesize(operand, grouped))
+	}
+
+	return strings.Join(operands, " "+operator.symbol+" "), operator.precedence, nil
+}
+
+// infixTernary writes if(condition, then, else) as condition ? then : else.
+func (p *printer) infixTernary(node ast.Expression) (string, int, error) {
+	parts := make([]string, 0, len(node.KeyArgs))
+
+	for i, arg := range node.KeyArgs {
+		part, precedence, err := p.infix(arg.Node)
+		if err != nil {
+			return "", 0, err
+		}
+
+		// The else branch can be another ternary without parentheses, since it is right associative.
+		isElse := i == len(node.KeyArgs)-1
+		parts = append(parts, parenthesize(part, !isElse && precedence == precedenceTernary))
+	}
+
+	return parts[0] + " ? " + parts[1] + " : " + parts[2], precedenceTernary, nil
+}
+
+// infixNot writes not(equal(a, b)) as a != b, and other not nodes with the '!' operator.
+func (p *printer) infixNot(node ast.Expression) (string, int, error) {
+	if equal, ok := node.KeyArgs[0].Node.(ast.Expression); ok && equal.Scalar == "equal" && len(equal.KeyArgs) == 2 && !hasCases(equal) {
+		first, firstPrecedence, err := p.infix(equal.KeyArgs[0].Node)
+		if err != nil {
+			return "", 0, err
+		}
+
+		second, secondPrecedence, err := p.infix(equal.KeyArgs[1].Node)
+		if err != nil {
+			return "", 0, err
+		}
+
+		first = parenthesize(first, firstPrecedence <= precedenceEquality)
+		second = parenthesize(second, secondPrecedence <= precedenceEquality)
+
+		return first + " != " + second, precedenceEquality, nil
+	}
+
+	operand, precedence, err := p.infix(node.KeyArgs[0].Node)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return "!" + parenthesize(operand, precedence < precedenceUnary), precedenceUnary, nil
+}
+
+// infixCall writes the node as a function call, with its arguments in the infix syntax.
+func (p *printer) infixCall(node ast.Expression) (string, int, error) {
+	args := make([]string, 0, len(node.KeyArgs))
+
+	for i, arg := range node.KeyArgs {
+		key, err := p.key(node.KeyArgs, i)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if arg.Case != nil {
+			caseValue, _, err := p.infix(arg.Case)
+			if err != nil {
+				return "", 0, err
+			}
+
+			key = caseValue + ": "
+		}
+
+		value, _, err := p.infix(arg.Node)
+		if err != nil {
+			return "", 0, err
+		}
+
+		args = append(args, key+value)
+	}
+
+	return node.Scalar + "(" + strings.Join(args, ", ") + ")", precedencePrimary, nil
+}
+
+func parenthesize(expression string, grouped bool) string {
+	if grouped {
+		return "(" + expression + ")"
+	}
+
+	return expression
+}
+
+func hasCases(node ast.Expression) bool {
+	for _, arg := range node.KeyArgs {
+		if arg.Case != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isExpression reports whether the node is an expression with the given scalar.
+func isExpression(node ast.AstNode, scalar string) bool {
+	expression, ok := node.(ast.Expression)
+	return ok && expression.Scalar == scalar
+}
@@ -0,0 +1,302 @@
+package encoding
+
+import (
+	"bytes"
+	"slices"
+)
+
+// infixLevels are the binary operators, from the lowest precedence to the highest.
+var infixLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+// infixScalars are the nodes built by each binary operator.
+// The operator '!=' is built as not(equal(a, b)).
+var infixScalars = map[string]string{
+	"||": "or",
+	"&&": "and",
+	"==": "equal",
+	"!=": "equal",
+	"<":  "lt",
+	"<=": "lte",
+	">":  "gt",
+	">=": "gte",
+	"+":  "sum",
+	"-":  "sub",
+	"*":  "mul",
+	"/":  "div",
+}
+
+// variadicScalars are the nodes that take any number of arguments, so chained operators are flattened.
+// Example: a + b + c is parsed as sum(a, b, c).
+var variadicScalars = map[string]struct{}{
+	"or":  {},
+	"and": {},
+	"sum": {},
+	"mul": {},
+}
+
+// infixParser parses the infix syntax into the same nodes as the regular parser.
+type infixParser struct {
+	*parser
+}
+
+func newInfixParser(input []byte, tokens []Token) *infixParser {
+	return &infixParser{
+		parser: newParser(input, tokens),
+	}
+}
+
+// parse parses an expression, including the ternary operator: condition ? then : else.
+func (p *infixParser) parse() (*Node, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isSymbol("?") {
+		return condition, nil
+	}
+
+	p.consume()
+
+	thenBranch, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+
+	elseBranch, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.expression("if", condition.Pos, condition, thenBranch, elseBranch), nil
+}
+
+// parseBinary parses the operators of the given precedence level, and the levels above it.
+// Operators are left associative, except for comparisons, which cannot be chained.
+func (p *infixParser) parseBinary(level int) (*Node, error) {
+	if level == len(infixLevels) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var chained *Node
+
+	for p.isSymbol(infixLevels[level]...) {
+		operator := p.consume()
+
+		if chained != nil && isComparison(string(operator.content)) {
+			return nil, p.errorAt(operator.pos, "comparison '%s' cannot be chained", operator.content)
+		}
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		scalar := infixScalars[string(operator.content)]
+
+		// Chains of the same variadic operator are flattened, unless grouped by parentheses.
+		if _, ok := variadicScalars[scalar]; ok && chained != nil && string(chained.Scalar) == scalar {
+			chained.Children = append(chained.Children, right)
+			chained.End = right.End
+
+			continue
+		}
+
+		chained = p.expression(scalar, left.Pos, left, right)
+
+		if string(operator.content) == "!=" {
+			chained = p.expression("not", left.Pos, chained)
+		}
+
+		left = chained
+	}
+
+	return left, nil
+}
+
+// parseUnary parses the '!' and '-' prefix operators.
+// The '-' operator is only supported before number literals.
+func (p *infixParser) parseUnary() (*Node, error) {
+	switch {
+	case p.isSymbol("!"):
+		operator := p.consume()
+
+		expression, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.expression("not", positionOf(p.input, operator.pos), expression), nil
+	case p.isSymbol("-"):
+		operator := p.consume()
+
+		next := p.peek()
+		negated := string(slices.Concat(operator.content, next.content))
+		if p.done() || next.pos != operator.end || !isNumber(negated) && !isSpecialFloat(negated) {
+			return nil, p.errorAt(operator.pos, "unary '-' is only supported before numbers")
+		}
+
+		p.consume()
+
+		return p.literal(Token{
+			content: slices.Concat(operator.content, next.content),
+			pos:     operator.pos,
+			end:     next.end,
+		})
+	default:
+		return p.parsePrimary()
+	}
+}
+
+// parsePrimary parses parentheses, function calls, literals and references.
+func (p *infixParser) parsePrimary() (*Node, error) {
+	if p.done() {
+		return nil, p.errorAt(len(p.input), "unexpected end of input")
+	}
+
+	token := p.consume()
+
+	switch {
+	case bytes.Equal(token.content, []byte("(")):
+		node, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case symbolAt(token.content, infixSymbols) == len(token.content):
+		return nil, p.errorAt(token.pos, "unexpected '%s'", token.content)
+	case p.isSymbol("(") && p.peek().pos == token.end:
+		return p.parseCall(token)
+	default:
+		return p.literal(token)
+	}
+}
+
+// parseCall parses the arguments of a node written as a function call, like in the regular syntax.
+// Arguments are separated by commas, and can be named, or be cases of switch and cond.
+func (p *infixParser) parseCall(name Token) (*Node, error) {
+	p.consume() // skip '('
+
+	node := &Node{
+		Scalar:   name.content,
+		Children: []*Node{},
+		Pos:      positionOf(p.input, name.pos),
+	}
+
+	for !p.done() && !p.isSymbol(")") {
+		if len(node.Children) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		var paramName Token
+		if p.isNext([]byte(":")) && isParamName(name.content, p.peek().content) {
+			paramName = p.consume()
+			p.consume() // skip ':'
+		}
+
+		child, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		if paramName.content == nil && p.isSymbol(":") {
+			p.consume()
+
+			caseNode := child
+
+			child, err = p.parse()
+			if err != nil {
+				return nil, err
+			}
+
+			child.Case = caseNode
+		}
+
+		child.Key = paramName.content
+		node.Children = append(node.Children, child)
+	}
+
+	if p.done() {
+		return nil, p.errorAt(name.pos, "missing ')' for '%s'", name.content)
+	}
+
+	p.consume()
+	node.End = p.tokens[p.index-1].end
+
+	return node, nil
+}
+
+// literal parses a literal or reference token.
+func (p *infixParser) literal(token Token) (*Node, error) {
+	node, err := p.parseValue(token)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Pos = positionOf(p.input, token.pos)
+	node.End = token.end
+
+	return node, nil
+}
+
+// expression returns an expression node with unnamed arguments, ending at the last consumed token.
+func (p *infixParser) expression(scalar string, pos Position, children ...*Node) *Node {
+	return &Node{
+		Scalar:   []byte(scalar),
+		Children: children,
+		Pos:      pos,
+		End:      p.tokens[p.index-1].end,
+	}
+}
+
+// expect consumes the symbol, or returns an error if the next token is another one.
+func (p *infixParser) expect(symbol string) error {
+	if p.done() {
+		return p.errorAt(len(p.input), "expected '%s', got end of input", symbol)
+	}
+
+	if !p.isSymbol(symbol) {
+		return p.errorAt(p.peek().pos, "expected '%s', got '%s'", symbol, p.peek().content)
+	}
+
+	p.consume()
+
+	return nil
+}
+
+// isSymbol reports whether the next token is one of the symbols.
+func (p *infixParser) isSymbol(symbols ...string) bool {
+	return !p.done() && slices.Contains(symbols, string(p.peek().content))
+}
+
+func isComparison(operator string) bool {
+	switch operator {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+
+	return false
+}
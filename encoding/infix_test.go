@@ -0,0 +1,126 @@
+package encoding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeInfix(t *testing.T) {
+	testCases := []struct {
+		infix   string
+		regular string
+	}{
+		{`person.age >= 18 ? "pass" : "fail"`, `if(gte(person.age, 18), "pass", "fail")`},
+		{`a || b && !c`, `or(a, and(b, not(c)))`},
+		{`a + b * c - d / 2`, `sub(sum(a, mul(b, c)), div(d, 2))`},
+		{`a + b + c`, `sum(a, b, c)`},
+		{`(a + b) + c`, `sum(sum(a, b), c)`},
+		{`a - b + c`, `sum(sub(a, b), c)`},
+		{`a != "b"`, `not(equal(a, "b"))`},
+		{`-5 + x > -1.5`, `gt(sum(-5, x), -1.5)`},
+		{`a ? b : c ? d : e`, `if(a, b, if(c, d, e))`},
+		{`!(a == 1)`, `not(equal(a, 1))`},
+		{`switch(tier, "gold": 0.2, default: 0.0) > 0.1`, `gt(switch(tier, "gold": 0.2, default: 0.0), 0.1)`},
+		{`if(condition: a > 1, then: x * 2) // comment`, `if(gt(a, 1), mul(x, 2))`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.infix, func(t *testing.T) {
+			expected, err := Decode([]byte(tc.regular), DefaultExpressionCodex)
+			require.NoError(t, err)
+
+			got, err := DecodeInfix([]byte(tc.infix), DefaultExpressionCodex)
+			require.NoError(t, err)
+
+			require.Equal(t, compact(t, expected), compact(t, got))
+		})
+	}
+}
+
+func Test_DecodeInfix_SyntaxError(t *testing.T) {
+	testCases := []struct {
+		input string
+		err   string
+	}{
+		{`a < b < c`, "1:7: comparison '<' cannot be chained"},
+		{`(a + b`, "1:7: expected ')', got end of input"},
+		{`a +`, "1:4: unexpected end of input"},
+		{`- x`, "1:1: unary '-' is only supported before numbers"},
+		{`not(a,)`, "1:7: unexpected ')'"},
+		{`a ? b`, "1:6: expected ':', got end of input"},
+		{`a b`, "1:3: unexpected 'b' after expression"},
+		{`unknown(a)`, "1:1: codex for 'unknown' not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := DecodeInfix([]byte(tc.input), DefaultExpressionCodex)
+
+			var syntaxErr SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			require.Equal(t, tc.err, syntaxErr.Error())
+		})
+	}
+}
+
+// compact returns the node in the compact regular syntax, for comparing trees.
+func compact(t *testing.T, node adapters.Node) string {
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, HumanEncode(buf, node, Compact()))
+
+	return buf.String()
+}
+
+func Test_HumanEncode_Infix(t *testing.T) {
+	testCases := []struct {
+		regular string
+		infix   string
+	}{
+		{`if(gte(person.age, 18), "pass", "fail")`, `person.age >= 18 ? "pass" : "fail"`},
+		{`if(gte(person.age, 18), "pass")`, `if(condition: person.age >= 18, then: "pass")`},
+		{`and(or(a, b), not(c))`, `(a || b) && !c`},
+		{`sub(a, sub(b, c))`, `a - (b - c)`},
+		{`sub(sub(a, b), c)`, `a - b - c`},
+		{`sum(sum(a, b), c)`, `(a + b) + c`},
+		{`mul(sum(a, 1), -2)`, `(a + 1) * -2`},
+		{`not(equal(a, "b"))`, `a != "b"`},
+		{`not(not(equal(a, "b")))`, `!(a != "b")`},
+		{`equal(gt(a, b), true)`, `a > b == true`},
+		{`if(if(a, b, c), d, if(e, f, g))`, `(a ? b : c) ? d : e ? f : g`},
+		{`switch(tier, "gold": sum(a, 1), default: 0)`, `switch(value: tier, "gold": a + 1, default: 0)`},
+		{`isEmpty(sum(a, b))`, `isEmpty(a + b)`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.regular, func(t *testing.T) {
+			node, err := Decode([]byte(tc.regular), DefaultExpressionCodex)
+			require.NoError(t, err)
+
+			buf := bytes.NewBuffer(nil)
+			require.NoError(t, HumanEncode(buf, node, Infix()))
+			require.Equal(t, tc.infix, buf.String())
+
+			decoded, err := DecodeInfix(buf.Bytes(), DefaultExpressionCodex)
+			require.NoError(t, err)
+			require.Equal(t, compact(t, node), compact(t, decoded))
+		})
+	}
+
+	t.Run("should not encode references with dashes", func(t *testing.T) {
+		node, err := Decode([]byte(`equal(user-id, 1)`), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		err = HumanEncode(bytes.NewBuffer(nil), node, Infix())
+		require.ErrorContains(t, err, "user-id")
+
+		subtraction, err := Decode([]byte(`equal(sub(user, id), 1)`), DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		decoded, err := DecodeInfix([]byte(`user-id == 1`), DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, compact(t, subtraction), compact(t, decoded))
+	})
+}
@@ -1,51 +1,118 @@
 package encoding
 
-func isInteger(s string) bool {
-	if s == "" {
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sonalys/gon/decimal"
+)
+
+// specialFloats are the literals of non-finite floats, so they decode as floats instead of references.
+var specialFloats = map[string]float64{
+	"Inf":  math.Inf(1),
+	"+Inf": math.Inf(1),
+	"-Inf": math.Inf(-1),
+	"NaN":  math.NaN(),
+}
+
+// isSpecialFloat reports whether the token is the literal of a non-finite float. Example: -Inf.
+func isSpecialFloat(s string) bool {
+	_, ok := specialFloats[s]
+	return ok
+}
+
+// isNumber reports whether the token starts like a number literal: a digit, or a dot followed by a digit, after an optional sign.
+// Tokens starting like numbers are never references, so malformed numbers are syntax errors.
+func isNumber(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	s = strings.TrimPrefix(s, ".")
+
+	return s != "" && '0' <= s[0] && s[0] <= '9'
+}
+
+// isFloatLiteral reports whether the number literal has a fraction or an exponent. Example: 1.5, 1e6 or 0x1p-2.
+func isFloatLiteral(s string) bool {
+	if isHex(s) {
+		return strings.ContainsAny(s, ".pP")
+	}
+
+	return strings.ContainsAny(s, ".eE")
+}
+
+func isHex(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")
+}
+
+// inExponent reports whether the token is a number literal ending with an exponent marker,
+// so a following sign is part of the literal. Example: 1e in 1e-5.
+func inExponent(token []byte) bool {
+	s := string(token)
+	if !isNumber(s) {
 		return false
 	}
-	// Allow leading + or -
-	if s[0] == '+' || s[0] == '-' {
-		s = s[1:]
+
+	if isHex(s) {
+		return strings.HasSuffix(s, "p") || strings.HasSuffix(s, "P")
 	}
-	if s == "" {
-		return false
+
+	return strings.HasSuffix(s, "e") || strings.HasSuffix(s, "E")
+}
+
+// parseNumber parses a Go number literal, including hexadecimal, octal and binary integers, exponents and digit separators.
+// Float literals are parsed as float64, decimal literals with the 'd' suffix as decimal.Decimal, and the others as int64.
+// Example: 12.50d.
+func parseNumber(s string) (any, error) {
+	if digits, ok := strings.CutSuffix(s, "d"); ok && !isHex(s) {
+		number, err := decimal.Parse(digits)
+		if err != nil {
+			return nil, err
+		}
+
+		return number, nil
 	}
-	for _, r := range s {
-		if r < '0' || r > '9' {
-			return false
+
+	if !isFloatLiteral(s) {
+		integer, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return nil, numberError(err)
 		}
+
+		return integer, nil
 	}
-	return true
-}
 
-func isFloat(s string) bool {
-	if s == "" {
-		return false
+	float, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, numberError(err)
 	}
-	// Allow leading + or -
-	if s[0] == '+' || s[0] == '-' {
-		s = s[1:]
+
+	return float, nil
+}
+
+// numberError returns the cause of a strconv error, like invalid syntax or value out of range.
+func numberError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Err
 	}
-	if s == "" {
-		return false
+
+	return err
+}
+
+// formatFloat returns the float in the shortest form that decodes back as a float. Example: 2 is written as 2.0.
+// Infinities are written as Inf and -Inf, and NaN as NaN, without a '+' that the infix syntax would parse as an operator.
+func formatFloat(value float64, bitSize int) string {
+	if math.IsInf(value, 1) {
+		return "Inf"
 	}
 
-	dotSeen := false
-	digitSeen := false
-	for _, r := range s {
-		switch {
-		case r >= '0' && r <= '9':
-			digitSeen = true
-		case r == '.':
-			if dotSeen {
-				return false
-			}
-			dotSeen = true
-		default:
-			return false
-		}
+	formatted := strconv.FormatFloat(value, 'g', -1, bitSize)
+
+	if strings.ContainsAny(formatted, ".eIN") {
+		return formatted
 	}
-	// Must contain at least one digit
-	return digitSeen && dotSeen
+
+	return formatted + ".0"
 }
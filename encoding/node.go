@@ -2,45 +2,187 @@ package encoding
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
 	"github.com/sonalys/gon/internal/nodes"
 )
 
 type Node struct {
 	Children []*Node
 	Key      []byte
+	// Case is the expression before ':' in case arguments, like in switch and cond.
+	Case     *Node
 	Scalar   []byte
 	Value    any
 	Type     adapters.NodeType
+	Comments ast.Comments
+	// Pos is the position of the first token of the node.
+	Pos Position
+	// End is the byte offset after the last token of the node.
+	End int
 }
 
 func translateNode(rootNode *Node, codex Codex) (adapters.Node, error) {
+	return translateNodeWithMetadata(rootNode, codex, nil, nil)
+}
+
+// translateNodeWithMetadata translates the node, storing the comments and the span of each translated node.
+// When comments or spans are nil, they are discarded.
+func translateNodeWithMetadata(rootNode *Node, codex Codex, comments ast.CommentMap, spans adapters.SpanMap) (adapters.Node, error) {
 	switch rootNode.Type {
 	case adapters.NodeTypeReference:
-		return nodes.Reference(string(rootNode.Scalar)), nil
+		node := nodes.Reference(string(rootNode.Scalar))
+		attachComments(comments, node, rootNode.Comments)
+		attachSpan(spans, node, rootNode)
+		return node, nil
 	case adapters.NodeTypeLiteral:
-		return nodes.Literal(rootNode.Value), nil
+		node := nodes.Literal(rootNode.Value)
+		attachComments(comments, node, rootNode.Comments)
+		attachSpan(spans, node, rootNode)
+		return node, nil
 	}
 
 	constructor, ok := codex[string(rootNode.Scalar)]
 	if !ok {
-		return nil, fmt.Errorf("codex for '%s' not found", rootNode.Scalar)
+		return nil, SyntaxError{
+			Position: rootNode.Pos,
+			Cause:    fmt.Errorf("codex for '%s' not found", rootNode.Scalar),
+		}
 	}
 
 	children := rootNode.Children
 	nodeChildren := make([]adapters.KeyNode, 0, len(children))
 
 	for _, child := range children {
-		childNode, err := translateNode(child, codex)
+		childNode, err := translateNodeWithMetadata(child, codex, comments, spans)
 		if err != nil {
 			return nil, err
 		}
+
+		var caseNode adapters.Node
+		if child.Case != nil {
+			if caseNode, err = translateNodeWithMetadata(child.Case, codex, comments, spans); err != nil {
+				return nil, err
+			}
+		}
+
 		nodeChildren = append(nodeChildren, adapters.KeyNode{
 			Key:  string(child.Key),
+			Case: caseNode,
 			Node: childNode,
 		})
 	}
 
-	return constructor(nodeChildren)
+	node, err := constructor(nodeChildren)
+	if err != nil {
+		return nil, SyntaxError{
+			Position: rootNode.Pos,
+			Cause:    fmt.Errorf("building '%s': %w", rootNode.Scalar, err),
+		}
+	}
+
+	attachSpan(spans, node, rootNode)
+
+	if comments == nil {
+		return node, nil
+	}
+
+	nodeComments := rootNode.Comments
+
+	// Constructors can replace their arguments, like exists or coalesce do with definition names.
+	// Comments of arguments that are not in the node shape are moved to the node.
+	shape := shapeNodes(node)
+
+	for i, child := range nodeChildren {
+		if ast.HasIdentity(child.Node) {
+			if _, ok := shape[child.Node]; ok {
+				continue
+			}
+		}
+
+		hoisted := detachComments(comments, child.Node)
+		if !ast.HasIdentity(child.Node) {
+			hoisted = mergeComments(children[i].Comments, hoisted)
+		}
+
+		nodeComments = mergeComments(nodeComments, hoisted)
+	}
+
+	attachComments(comments, node, nodeComments)
+
+	return node, nil
+}
+
+// attachComments stores the comments of the node, if there are any.
+// Nodes without identity are skipped, since they cannot be matched when encoding.
+func attachComments(comments ast.CommentMap, node adapters.Node, nodeComments ast.Comments) {
+	if comments == nil || nodeComments.IsEmpty() || !ast.HasIdentity(node) {
+		return
+	}
+
+	comments[node] = nodeComments
+}
+
+// attachSpan stores the source span of the node, if it has identity.
+func attachSpan(spans adapters.SpanMap, node adapters.Node, rootNode *Node) {
+	if spans == nil || !ast.HasIdentity(node) {
+		return
+	}
+
+	spans[node] = adapters.Span{
+		Start:  rootNode.Pos.Offset,
+		End:    rootNode.End,
+		Line:   rootNode.Pos.Line,
+		Column: rootNode.Pos.Column,
+	}
+}
+
+// detachComments removes and returns the comments of the node and all its children.
+func detachComments(comments ast.CommentMap, node adapters.Node) ast.Comments {
+	var detached ast.Comments
+
+	if ast.HasIdentity(node) {
+		detached = comments[node]
+		delete(comments, node)
+	}
+
+	if serializable, ok := node.(adapters.SerializableNode); ok && serializable.Type() == adapters.NodeTypeExpression {
+		for _, arg := range serializable.Shape() {
+			if arg.Case != nil {
+				detached = mergeComments(detached, detachComments(comments, arg.Case))
+			}
+
+			detached = mergeComments(detached, detachComments(comments, arg.Node))
+		}
+	}
+
+	return detached
+}
+
+// shapeNodes returns the arguments of the node that can hold comments.
+func shapeNodes(node adapters.Node) map[adapters.Node]struct{} {
+	serializable, ok := node.(adapters.SerializableNode)
+	if !ok || serializable.Type() != adapters.NodeTypeExpression {
+		return nil
+	}
+
+	shape := serializable.Shape()
+	reachable := make(map[adapters.Node]struct{}, len(shape))
+
+	for _, arg := range shape {
+		if ast.HasIdentity(arg.Node) {
+			reachable[arg.Node] = struct{}{}
+		}
+	}
+
+	return reachable
+}
+
+func mergeComments(a, b ast.Comments) ast.Comments {
+	return ast.Comments{
+		Leading:  slices.Concat(a.Leading, b.Leading),
+		Trailing: slices.Concat(a.Trailing, b.Trailing),
+	}
 }
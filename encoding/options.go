@@ -1,5 +1,7 @@
 package encoding
 
+import "github.com/sonalys/gon/ast"
+
 type prettyOpt struct{}
 
 func (p prettyOpt) applyHumanEncodeOption(opt *humanEncodeConfig) {
@@ -19,3 +21,42 @@ func Compact() *prettyOpt {
 func Unnamed() *hideParamName {
 	return &hideParamName{}
 }
+
+type infixOpt struct{}
+
+func (i infixOpt) applyHumanEncodeOption(opt *humanEncodeConfig) {
+	opt.infix = true
+}
+
+// Infix encodes the node in the infix syntax decoded by DecodeInfix, in a single line.
+// Nodes with an operator are written with it, and the others as function calls. Example: person.age >= 18 ? "pass" : "fail".
+// Comments are not written. References containing '-' can't be decoded by DecodeInfix, so they fail to encode.
+func Infix() infixOpt {
+	return infixOpt{}
+}
+
+type lineWidthOpt int
+
+func (l lineWidthOpt) applyHumanEncodeOption(opt *humanEncodeConfig) {
+	opt.lineWidth = int(l)
+}
+
+// LineWidth sets the maximum line width, before an expression is broken into multiple lines.
+// Tabs are measured as 4 columns.
+func LineWidth(width int) lineWidthOpt {
+	return lineWidthOpt(width)
+}
+
+type commentsOpt struct {
+	comments ast.CommentMap
+}
+
+func (c commentsOpt) applyHumanEncodeOption(opt *humanEncodeConfig) {
+	opt.comments = c.comments
+}
+
+// WithComments writes the comments attached to each node, as returned by DecodeWithComments.
+// In compact mode, line comments are written as block comments.
+func WithComments(comments ast.CommentMap) commentsOpt {
+	return commentsOpt{comments: comments}
+}
@@ -3,23 +3,58 @@ package encoding
 import (
 	"bytes"
 	"fmt"
-	"strconv"
+	"slices"
 
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
 )
 
 type parser struct {
 	tokens []Token
 	index  int
+
+	input        []byte
+	comments     []Token
+	commentIndex int
+}
+
+func newParser(input []byte, tokens []Token) *parser {
+	return &parser{
+		tokens: tokens,
+		input:  input,
+	}
 }
 
-func newParser(tokens []Token) *parser {
-	return &parser{tokens: tokens}
+// newCommentParser returns a parser that attaches the comments to the parsed nodes.
+func newCommentParser(input []byte, tokens, comments []Token) *parser {
+	return &parser{
+		tokens:   tokens,
+		input:    input,
+		comments: comments,
+	}
 }
 
+// parse parses the next node, with its leading and trailing comments.
 func (p *parser) parse() (*Node, error) {
-	if p.isNext([]byte(":")) {
-		return nil, fmt.Errorf("invalid syntax")
+	leading := p.leadingComments()
+	pos := p.position(p.peek().pos)
+
+	node, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+
+	node.Pos = pos
+	node.End = p.tokens[p.index-1].end
+	node.Comments.Leading = append(leading, node.Comments.Leading...)
+	node.Comments.Trailing = append(node.Comments.Trailing, p.trailingComments()...)
+
+	return node, nil
+}
+
+func (p *parser) parseNode() (*Node, error) {
+	if p.done() {
+		return nil, p.errorAt(len(p.input), "unexpected end of input")
 	}
 
 	if p.isNext([]byte("(")) {
@@ -32,23 +67,54 @@ func (p *parser) parse() (*Node, error) {
 		}
 
 		for !p.done() && !bytes.Equal(p.peek().content, []byte(")")) {
-			if p.isNext([]byte(":")) {
-				paramName := p.consume()
+			// Comments before the parameter name belong to its value.
+			leading := p.leadingComments()
+
+			var paramName Token
+			if p.isNext([]byte(":")) && isParamName(name.content, p.peek().content) {
+				paramName = p.consume()
+				p.consume() // skip ':'
+			}
+
+			childNode, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+
+			// Any other value followed by ':' is the case of the following value, like in switch and cond.
+			if paramName.content == nil && bytes.Equal(p.peek().content, []byte(":")) {
 				p.consume() // skip ':'
-				childNode, err := p.parse()
+
+				caseNode := childNode
+
+				childNode, err = p.parse()
 				if err != nil {
 					return nil, err
 				}
-				childNode.Key = paramName.content
-				node.Children = append(node.Children, childNode)
-				continue
+
+				// Comments of the case are kept with its value, since they are encoded together.
+				childNode.Comments.Leading = slices.Concat(caseNode.Comments.Leading, caseNode.Comments.Trailing, childNode.Comments.Leading)
+				caseNode.Comments = ast.Comments{}
+				childNode.Case = caseNode
 			}
 
-			val, err := p.parse()
-			if err != nil {
-				return nil, err
+			childNode.Key = paramName.content
+			childNode.Comments.Leading = append(leading, childNode.Comments.Leading...)
+			node.Children = append(node.Children, childNode)
+		}
+
+		// Comments after the last argument are kept with it, or with the expression if it has no arguments.
+		if dangling := p.leadingComments(); len(dangling) > 0 {
+			if len(node.Children) > 0 {
+				last := node.Children[len(node.Children)-1]
+				last.Comments.Trailing = append(last.Comments.Trailing, dangling...)
+			} else {
+				node.Comments.Trailing = append(node.Comments.Trailing, dangling...)
 			}
-			node.Children = append(node.Children, val)
+		}
+
+		if p.done() {
+			return nil, p.errorAt(name.pos, "missing ')' for '%s'", name.content)
 		}
 
 		p.consumeExpected([]byte(")"))
@@ -56,29 +122,36 @@ func (p *parser) parse() (*Node, error) {
 		return node, nil
 	}
 
-	switch token := p.consume(); {
+	token := p.consume()
+	if symbolAt(token.content, symbols) == len(token.content) {
+		return nil, p.errorAt(token.pos, "unexpected '%s'", token.content)
+	}
+
+	return p.parseValue(token)
+}
+
+// parseValue parses a literal or reference token.
+func (p *parser) parseValue(token Token) (*Node, error) {
+	switch {
+	case bytes.HasPrefix(token.content, []byte("\"")) && (len(token.content) == 1 || !bytes.HasSuffix(token.content, []byte("\""))):
+		return nil, p.errorAt(token.pos, "unterminated string")
 	case bytes.HasPrefix(token.content, []byte("\"")) && bytes.HasSuffix(token.content, []byte("\"")):
 		val := bytes.Trim(token.content, "\"")
 		return &Node{Value: string(val), Type: adapters.NodeTypeLiteral}, nil
-	case isInteger(string(token.content)):
-		integer, err := strconv.ParseInt(string(token.content), 10, 64)
-		if err != nil {
-			return nil, err
-		}
-
+	case isSpecialFloat(string(token.content)):
 		return &Node{
 			Type:  adapters.NodeTypeLiteral,
-			Value: integer,
+			Value: specialFloats[string(token.content)],
 		}, nil
-	case isFloat(string(token.content)):
-		float, err := strconv.ParseFloat(string(token.content), 64)
+	case isNumber(string(token.content)):
+		number, err := parseNumber(string(token.content))
 		if err != nil {
-			return nil, err
+			return nil, p.errorAt(token.pos, "invalid number '%s': %w", token.content, err)
 		}
 
 		return &Node{
 			Type:  adapters.NodeTypeLiteral,
-			Value: float,
+			Value: number,
 		}, nil
 	default:
 		switch string(token.content) {
@@ -92,6 +165,10 @@ func (p *parser) parse() (*Node, error) {
 				Type:  adapters.NodeTypeLiteral,
 				Value: false,
 			}, nil
+		case "null":
+			return &Node{
+				Type: adapters.NodeTypeLiteral,
+			}, nil
 		default:
 			return &Node{
 				Type:   adapters.NodeTypeReference,
@@ -133,3 +210,99 @@ func (p *parser) consumeExpected(t []byte) {
 func (p *parser) done() bool {
 	return p.index >= len(p.tokens)
 }
+
+// end returns an error if there are tokens left after the parsed expression.
+func (p *parser) end() error {
+	if p.done() {
+		return nil
+	}
+
+	token := p.peek()
+
+	return p.errorAt(token.pos, "unexpected '%s' after expression", token.content)
+}
+
+func (p *parser) position(offset int) Position {
+	if p.done() {
+		offset = len(p.input)
+	}
+
+	return positionOf(p.input, offset)
+}
+
+func (p *parser) errorAt(offset int, format string, args ...any) error {
+	return SyntaxError{
+		Position: positionOf(p.input, offset),
+		Cause:    fmt.Errorf(format, args...),
+	}
+}
+
+// leadingComments consumes the comments before the next token, or until the end of the input.
+func (p *parser) leadingComments() []string {
+	var comments []string
+
+	for p.commentIndex < len(p.comments) {
+		comment := p.comments[p.commentIndex]
+		if !p.done() && comment.pos > p.peek().pos {
+			break
+		}
+
+		comments = append(comments, string(comment.content))
+		p.commentIndex++
+	}
+
+	return comments
+}
+
+// trailingComments consumes the comments in the same line as the previous token.
+func (p *parser) trailingComments() []string {
+	if p.index == 0 {
+		return nil
+	}
+
+	var comments []string
+
+	prevEnd := p.tokens[p.index-1].end
+
+	for p.commentIndex < len(p.comments) {
+		comment := p.comments[p.commentIndex]
+		if !p.done() && comment.pos > p.peek().pos {
+			break
+		}
+
+		if bytes.IndexByte(p.input[prevEnd:comment.pos], '\n') != -1 {
+			break
+		}
+
+		comments = append(comments, string(comment.content))
+		p.commentIndex++
+		prevEnd = comment.end
+	}
+
+	return comments
+}
+
+// caseParams are the parameter names of the nodes with case arguments.
+// Other identifiers followed by ':' are their cases, so references can be matched. Example: cond(user.vip: "yes", default: "no").
+var caseParams = map[string][]string{
+	"cond":   {"default"},
+	"switch": {"value", "default"},
+}
+
+// isParamName reports whether the token is an identifier, which names the parameter of the scalar when followed by ':'.
+func isParamName(scalar, token []byte) bool {
+	if len(token) == 0 || !('a' <= token[0] && token[0] <= 'z' || 'A' <= token[0] && token[0] <= 'Z') {
+		return false
+	}
+
+	if params, ok := caseParams[string(scalar)]; ok && !slices.Contains(params, string(token)) {
+		return false
+	}
+
+	switch string(token) {
+	case "true", "True", "false", "False", "null":
+		return false
+	}
+
+	return true
+}
@@ -2,6 +2,7 @@ package encoding
 
 import (
 	"bytes"
+	"errors"
 	"unicode"
 )
 
@@ -10,82 +11,129 @@ type Token struct {
 	pos, end int
 }
 
-func tokenize(input []byte) []Token {
-	var tokens []Token
+var (
+	lineCommentStart  = []byte("//")
+	blockCommentStart = []byte("/*")
+	blockCommentEnd   = []byte("*/")
+
+	// symbols are tokenized on their own, even without surrounding spaces.
+	symbols = [][]byte{[]byte("("), []byte(")"), []byte(":")}
+	// infixSymbols are the symbols of the infix syntax, with longer operators first.
+	infixSymbols = [][]byte{
+		[]byte("=="), []byte("!="), []byte("<="), []byte(">="), []byte("&&"), []byte("||"),
+		[]byte("<"), []byte(">"), []byte("!"), []byte("+"), []byte("-"), []byte("*"), []byte("/"),
+		[]byte("?"), []byte(":"), []byte("("), []byte(")"), []byte(","),
+	}
+)
+
+func tokenize(input []byte) ([]Token, error) {
+	tokens, _, err := tokenizeWithComments(input)
+	return tokens, err
+}
+
+// tokenizeWithComments splits the input into tokens, returning the comments separately.
+// Line comments start with '//' and end at the end of the line.
+// Block comments start with '/*' and end with '*/', or return a SyntaxError at their start when unterminated.
+// Comment markers inside strings are part of the string.
+func tokenizeWithComments(input []byte) (tokens, comments []Token, err error) {
+	return scan(input, symbols)
+}
+
+// tokenizeInfix splits the infix input into tokens, discarding comments.
+// Unlike tokenize, operators and commas are tokens.
+func tokenizeInfix(input []byte) ([]Token, error) {
+	tokens, _, err := scan(input, infixSymbols)
+	return tokens, err
+}
+
+// scan splits the input into tokens and comments, separating each of the given symbols.
+// Spaces, and commas that are not symbols, separate tokens.
+func scan(input []byte, symbols [][]byte) (tokens, comments []Token, err error) {
 	var curTokenStartIndex int
 	var inString bool
-	var inComment bool
 
-	for i, r := range input {
-		resetCursor := func() {
-			curTokenStartIndex = i + 1
-		}
-
-		getCurrent := func(inclusive bool) Token {
-			defer resetCursor()
-			if inclusive {
-				return Token{
-					content: input[curTokenStartIndex : i+1],
-					pos:     curTokenStartIndex,
-					end:     i + 1,
-				}
-			}
-			return Token{
-				content: input[curTokenStartIndex:i],
+	flush := func(end int) {
+		if end > curTokenStartIndex {
+			tokens = append(tokens, Token{
+				content: input[curTokenStartIndex:end],
 				pos:     curTokenStartIndex,
-				end:     i,
-			}
+				end:     end,
+			})
 		}
+		curTokenStartIndex = end
+	}
 
-		curLength := i - curTokenStartIndex
+	for i := 0; i < len(input); i++ {
+		r := input[i]
 
 		switch {
-		case r == '\n':
-			if !inComment {
-				if curLength > 0 {
-					tokens = append(tokens, getCurrent(false))
-				}
+		case inString:
+			if r == '"' {
+				flush(i + 1)
+				inString = false
 			}
-			inComment = false
-			resetCursor()
-		case inComment:
-		case len(input) > i+2 && bytes.Equal(input[i:i+2], []byte("//")):
-			inComment = true
-		case r == '"' && !inString:
+		case r == '"':
 			inString = true
-		case r == '"' && inString:
-			tokens = append(tokens, getCurrent(true))
-			inString = false
-		case inString:
-		case unicode.IsSpace(rune(r)):
-			if curLength > 0 {
-				tokens = append(tokens, getCurrent(false))
+		case bytes.HasPrefix(input[i:], lineCommentStart):
+			flush(i)
+
+			end := bytes.IndexByte(input[i:], '\n')
+			if end == -1 {
+				end = len(input)
+			} else {
+				end += i
 			}
-			resetCursor()
-		case bytes.Contains([]byte("():"), input[i:i+1]):
-			if curLength > 0 {
-				tokens = append(tokens, getCurrent(false))
+
+			comments = append(comments, Token{content: input[i:end], pos: i, end: end})
+			curTokenStartIndex = end
+			i = end - 1
+		case bytes.HasPrefix(input[i:], blockCommentStart):
+			flush(i)
+
+			end := bytes.Index(input[i+len(blockCommentStart):], blockCommentEnd)
+			if end == -1 {
+				return nil, nil, SyntaxError{
+					Position: positionOf(input, i),
+					Cause:    errors.New("unterminated comment"),
+				}
 			}
+
+			end += i + len(blockCommentStart) + len(blockCommentEnd)
+
+			comments = append(comments, Token{content: input[i:end], pos: i, end: end})
+			curTokenStartIndex = end
+			i = end - 1
+		case (r == '-' || r == '+') && inExponent(input[curTokenStartIndex:i]):
+			// The sign is part of the exponent, like in 1e-5.
+		case symbolAt(input[i:], symbols) > 0:
+			size := symbolAt(input[i:], symbols)
+
+			flush(i)
 			tokens = append(tokens, Token{
-				content: input[i : i+1],
+				content: input[i : i+size],
 				pos:     i,
-				end:     i + 1,
+				end:     i + size,
 			})
-			resetCursor()
-		case r == ',':
-			if curLength > 0 {
-				tokens = append(tokens, getCurrent(false))
-			}
-			resetCursor()
-		default:
+			curTokenStartIndex = i + size
+			i += size - 1
+		case unicode.IsSpace(rune(r)), r == ',':
+			flush(i)
+			curTokenStartIndex = i + 1
 		}
 	}
-	if curTokenStartIndex < len(input) {
-		tokens = append(tokens, Token{
-			content: input[curTokenStartIndex:],
-			pos:     curTokenStartIndex,
-			end:     len(input),
-		})
+
+	flush(len(input))
+
+	return tokens, comments, nil
+}
+
+// symbolAt returns the length of the symbol starting the input, or 0 if there is none.
+func symbolAt(input []byte, symbols [][]byte) int {
+	for _, symbol := range symbols {
+		if bytes.HasPrefix(input, symbol) {
+			return len(symbol)
+		}
 	}
-	return tokens
+
+	return 0
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_tokenize(t *testing.T) {
@@ -15,7 +16,8 @@ func Test_tokenize(t *testing.T) {
 		  ),
 		)`
 
-		tokens := tokenize([]byte(input))
+		tokens, err := tokenize([]byte(input))
+		require.NoError(t, err)
 		expectedTokens := []Token{
 			{content: []uint8{0x69, 0x66}, pos: 0, end: 2},
 			{content: []uint8{0x28}, pos: 2, end: 3},
@@ -44,7 +46,8 @@ func Test_tokenize(t *testing.T) {
 	t.Run("inlined", func(t *testing.T) {
 		input := `if(condition: equal(first: true,second: friend.name else: "third",),)`
 
-		tokens := tokenize([]byte(input))
+		tokens, err := tokenize([]byte(input))
+		require.NoError(t, err)
 		expectedTokens := []Token{
 			{content: []uint8{0x69, 0x66}, pos: 0, end: 2},
 			{content: []uint8{0x28}, pos: 2, end: 3},
@@ -73,7 +76,8 @@ func Test_tokenize(t *testing.T) {
 	t.Run("no space", func(t *testing.T) {
 		input := `if(condition:equal(first:true,second:friend.name,else:"third",),)`
 
-		tokens := tokenize([]byte(input))
+		tokens, err := tokenize([]byte(input))
+		require.NoError(t, err)
 		expectedTokens := []Token{
 			{content: []uint8{0x69, 0x66}, pos: 0, end: 2},
 			{content: []uint8{0x28}, pos: 2, end: 3},
@@ -102,7 +106,8 @@ func Test_tokenize(t *testing.T) {
 	t.Run("comment should be ignored", func(t *testing.T) {
 		input := "// comment\nif()"
 
-		tokens := tokenize([]byte(input))
+		tokens, err := tokenize([]byte(input))
+		require.NoError(t, err)
 		expectedTokens := []Token{
 			{content: []uint8{0x69, 0x66}, pos: 11, end: 13},
 			{content: []uint8{0x28}, pos: 13, end: 14},
@@ -114,3 +119,53 @@ func Test_tokenize(t *testing.T) {
 		assert.Equal(t, expectedTokens, tokens)
 	})
 }
+
+func Test_tokenizeWithComments(t *testing.T) {
+	t.Run("should return line and block comments", func(t *testing.T) {
+		input := "// lead\nif(/* inner */ a) // trail"
+
+		tokens, comments, err := tokenizeWithComments([]byte(input))
+		require.NoError(t, err)
+		assert.Equal(t, []Token{
+			{content: []byte("if"), pos: 8, end: 10},
+			{content: []byte("("), pos: 10, end: 11},
+			{content: []byte("a"), pos: 23, end: 24},
+			{content: []byte(")"), pos: 24, end: 25},
+		}, tokens)
+		assert.Equal(t, []Token{
+			{content: []byte("// lead"), pos: 0, end: 7},
+			{content: []byte("/* inner */"), pos: 11, end: 22},
+			{content: []byte("// trail"), pos: 26, end: 34},
+		}, comments)
+	})
+
+	t.Run("should not split tokens on comments", func(t *testing.T) {
+		tokens, comments, err := tokenizeWithComments([]byte("a/* c */b"))
+		require.NoError(t, err)
+		assert.Equal(t, []Token{
+			{content: []byte("a"), pos: 0, end: 1},
+			{content: []byte("b"), pos: 8, end: 9},
+		}, tokens)
+		assert.Len(t, comments, 1)
+	})
+
+	t.Run("should ignore comment markers inside strings", func(t *testing.T) {
+		tokens, comments, err := tokenizeWithComments([]byte(`f("http://host/*")`))
+		require.NoError(t, err)
+		assert.Equal(t, []Token{
+			{content: []byte("f"), pos: 0, end: 1},
+			{content: []byte("("), pos: 1, end: 2},
+			{content: []byte(`"http://host/*"`), pos: 2, end: 17},
+			{content: []byte(")"), pos: 17, end: 18},
+		}, tokens)
+		assert.Empty(t, comments)
+	})
+
+	t.Run("should reject unterminated block comments", func(t *testing.T) {
+		_, _, err := tokenizeWithComments([]byte("a /* open"))
+
+		var syntaxErr SyntaxError
+		require.ErrorAs(t, err, &syntaxErr)
+		assert.Equal(t, Position{Offset: 2, Line: 1, Column: 3}, syntaxErr.Position)
+	})
+}
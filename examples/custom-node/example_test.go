@@ -96,10 +96,7 @@ func Example_customNode() {
 	fmt.Println(value)
 
 	//Output:
-	// if(
-	// 	condition: customNode(myCustomParam: "my-param"),
-	// 	then: "works!"
-	// )
+	// if(condition: customNode(myCustomParam: "my-param"), then: "works!")
 	// got: my-param
 	// works!
 }
@@ -0,0 +1,126 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+)
+
+// featureFlagStore simulates a remote feature-flag service.
+type featureFlagStore struct {
+	flags map[string]bool
+}
+
+func (s *featureFlagStore) BatchGet(keys []string) map[string]bool {
+	fmt.Printf("round-trip: %s\n", strings.Join(keys, ", "))
+
+	resp := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if value, ok := s.flags[key]; ok {
+			resp[key] = value
+		}
+	}
+	return resp
+}
+
+// flagProvider resolves definitions under the "flags." prefix from the store.
+type flagProvider struct {
+	store *featureFlagStore
+
+	mu    sync.Mutex
+	cache map[string]bool
+}
+
+func (p *flagProvider) Provide(ctx context.Context, key string) (adapters.Value, bool, error) {
+	flag, ok := strings.CutPrefix(key, "flags.")
+	if !ok {
+		return nil, false, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if value, ok := p.cache[flag]; ok {
+		return gon.Literal(value), true, nil
+	}
+
+	resp := p.store.BatchGet([]string{flag})
+	value, ok := resp[flag]
+	if !ok {
+		return nil, false, nil
+	}
+
+	p.cache[flag] = value
+	return gon.Literal(value), true, nil
+}
+
+func (p *flagProvider) Prefetch(ctx context.Context, keys []string) error {
+	flags := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if flag, ok := strings.CutPrefix(key, "flags."); ok {
+			flags = append(flags, flag)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for flag, value := range p.store.BatchGet(flags) {
+		p.cache[flag] = value
+	}
+
+	return nil
+}
+
+func Example_definitionProvider() {
+	provider := &flagProvider{
+		store: &featureFlagStore{
+			flags: map[string]bool{
+				"new_checkout": false,
+				"beta_pricing": true,
+			},
+		},
+		cache: make(map[string]bool),
+	}
+
+	scope, err := gon.
+		NewScope().
+		WithProviders(provider).
+		WithValues(gon.Values{
+			"user": gon.Literal(map[string]string{"plan": "premium"}),
+		})
+	if err != nil {
+		panic(err)
+	}
+
+	ruleStr := `if(
+		condition: or(flags.new_checkout, flags.beta_pricing),
+		then: "beta",
+		else: user.plan
+	)`
+
+	rule, err := encoding.Decode([]byte(ruleStr), encoding.DefaultExpressionCodex)
+	if err != nil {
+		panic(err)
+	}
+
+	// Gather every definition the rule depends on, and fetch them in one round-trip.
+	if err := scope.Prefetch(rule); err != nil {
+		panic(err)
+	}
+
+	value, err := scope.Compute(rule)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(value)
+	// Output:
+	// round-trip: beta_pricing, new_checkout
+	// beta
+}
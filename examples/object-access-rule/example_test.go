@@ -60,18 +60,9 @@ func Example_objectAccessRule() {
 	// Output:
 	// if(
 	// 	condition: or(
-	// 		equal(
-	// 			first: file.uid,
-	// 			second: 1023
-	// 		),
-	// 		equal(
-	// 			first: file.gid,
-	// 			second: 102
-	// 		),
-	// 		hasPrefix(
-	// 			text: file.path,
-	// 			prefix: "/shared"
-	// 		)
+	// 		equal(first: file.uid, second: 1023),
+	// 		equal(first: file.gid, second: 102),
+	// 		hasPrefix(text: file.path, prefix: "/shared")
 	// 	),
 	// 	then: true,
 	// 	else: false
@@ -0,0 +1,91 @@
+package gon
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+	"github.com/sonalys/gon/internal/nodes"
+)
+
+// Functions defines Go functions callable by name from rules, like built-in nodes. Example: discount(order.total, 0.1).
+// Functions follow the same rules as call: the context is given automatically, arguments are converted to the parameter types,
+// and a trailing error result fails the evaluation.
+// The names follow the same rules as Values keys.
+type Functions map[string]any
+
+// Register registers each function name in the codex, so rules can be decoded with them.
+// Decoding checks the argument count, and the types of literal arguments, against the function signature.
+func (f Functions) Register(codex adapters.Codex) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(f)) {
+		typeOfFunc := reflect.TypeOf(f[name])
+
+		err := codex.Register(name, func(args []adapters.KeyNode) (adapters.Node, error) {
+			_, rest, err := gonutils.SortArgs(args)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := nodes.CheckFunctionArguments(typeOfFunc, rest); err != nil {
+				return nil, fmt.Errorf("function '%s': %w", name, err)
+			}
+
+			return nodes.Function(name, rest...), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f Functions) validate() error {
+	for name, fn := range f {
+		if !keyValidationRegex.MatchString(name) {
+			return adapters.InvalidDefinitionKey{
+				DefinitionKey: name,
+			}
+		}
+
+		if reflect.TypeOf(fn) == nil || reflect.TypeOf(fn).Kind() != reflect.Func || reflect.ValueOf(fn).IsNil() {
+			return fmt.Errorf("function '%s' should be a non-nil func, got %T", name, fn)
+		}
+	}
+
+	return nil
+}
+
+// WithFunctions returns a new scope with the given functions layered on top of the receiver.
+// Functions shadow the receiver functions with the same name, and are independent of definitions.
+func (s *scope) WithFunctions(functions Functions) (*scope, error) {
+	if err := functions.validate(); err != nil {
+		return nil, err
+	}
+
+	return &scope{
+		Context:   s.Context,
+		functions: maps.Clone(functions),
+		parent:    s,
+	}, nil
+}
+
+// Function resolves a function registered with WithFunctions, from the newest layer to the oldest.
+func (s *scope) Function(name string) (adapters.Callable, bool) {
+	for layer := s; layer != nil; layer = layer.parent {
+		if fn, ok := layer.functions[name]; ok {
+			return nodes.Literal(fn), true
+		}
+	}
+
+	return nil, false
+}
+
+var _ adapters.FunctionReader = &scope{}
@@ -0,0 +1,82 @@
+package gon_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Functions(t *testing.T) {
+	functions := gon.Functions{
+		"discount": func(total float64, rate float64) float64 {
+			return total * rate
+		},
+	}
+
+	codex := maps.Clone(encoding.DefaultExpressionCodex)
+	require.NoError(t, functions.Register(&codex))
+
+	t.Run("should call registered functions by name", func(t *testing.T) {
+		rule, err := encoding.Decode([]byte(`discount(total, 0.5)`), codex)
+		require.NoError(t, err)
+
+		scope, err := gon.NewScope().WithValues(gon.Values{"total": gon.Float(10)})
+		require.NoError(t, err)
+
+		scope, err = scope.WithFunctions(functions)
+		require.NoError(t, err)
+
+		value, err := scope.Compute(rule)
+		require.NoError(t, err)
+		require.Equal(t, 5.0, value)
+	})
+
+	t.Run("should check arity when decoding", func(t *testing.T) {
+		_, err := encoding.Decode([]byte(`discount(1.0)`), codex)
+		require.Error(t, err)
+	})
+
+	t.Run("should check literal types when decoding", func(t *testing.T) {
+		_, err := encoding.Decode([]byte(`discount(1.0, "half")`), codex)
+		require.ErrorAs(t, err, &adapters.TypeConversionError{})
+	})
+
+	t.Run("should error if the scope doesn't register the function", func(t *testing.T) {
+		rule, err := encoding.Decode([]byte(`discount(1.0, 0.5)`), codex)
+		require.NoError(t, err)
+
+		_, err = gon.NewScope().Compute(rule)
+		require.ErrorAs(t, err, &adapters.DefinitionNotFoundError{})
+	})
+
+	t.Run("should not conflict with definitions", func(t *testing.T) {
+		scope, err := gon.NewScope().WithValues(gon.Values{"discount": gon.Float(0.1)})
+		require.NoError(t, err)
+
+		scope, err = scope.WithFunctions(functions)
+		require.NoError(t, err)
+
+		value, err := scope.Compute(gon.Function("discount", gon.Reference("discount"), gon.Float(2)))
+		require.NoError(t, err)
+		require.Equal(t, 0.2, value)
+	})
+
+	t.Run("should conflict with built-in nodes", func(t *testing.T) {
+		codex := maps.Clone(encoding.DefaultExpressionCodex)
+
+		err := gon.Functions{"if": func() bool { return true }}.Register(&codex)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject invalid functions", func(t *testing.T) {
+		_, err := gon.NewScope().WithFunctions(gon.Functions{"discount": 0.1})
+		require.Error(t, err)
+
+		_, err = gon.NewScope().WithFunctions(gon.Functions{"!": func() {}})
+		require.ErrorAs(t, err, &adapters.InvalidDefinitionKey{})
+	})
+}
@@ -5,22 +5,72 @@ import (
 )
 
 var (
-	Avg            = nodes.Avg
-	Call           = nodes.Call
-	Coalesce       = nodes.Coalesce
-	Equal          = nodes.Equal
-	Exists         = nodes.Exists
-	Greater        = nodes.Greater
-	GreaterOrEqual = nodes.GreaterOrEqual
-	HasPrefix      = nodes.HasPrefix
-	HasSuffix      = nodes.HasSuffix
-	If             = nodes.If
-	IsEmpty        = nodes.IsEmpty
-	Literal        = nodes.Literal
-	Not            = nodes.Not
-	Or             = nodes.Or
-	Reference      = nodes.Reference
-	Smaller        = nodes.Smaller
-	SmallerOrEqual = nodes.SmallerOrEqual
-	Sum            = nodes.Sum
+	Abs              = nodes.Abs
+	And              = nodes.And
+	AtLeast          = nodes.AtLeast
+	AtMost           = nodes.AtMost
+	Avg              = nodes.Avg
+	AvgOf            = nodes.AvgOf
+	Call             = nodes.Call
+	CallWithKeywords = nodes.CallWithKeywords
+	Case             = nodes.Case
+	Ceil             = nodes.Ceil
+	Clamp            = nodes.Clamp
+	Coalesce         = nodes.Coalesce
+	Cond             = nodes.Cond
+	CountOf          = nodes.CountOf
+	Default          = nodes.Default
+	Difference       = nodes.Difference
+	Distinct         = nodes.Distinct
+	Div              = nodes.Div
+	Equal            = nodes.Equal
+	Error            = nodes.Error
+	Exactly          = nodes.Exactly
+	Exists           = nodes.Exists
+	First            = nodes.First
+	Flatten          = nodes.Flatten
+	Floor            = nodes.Floor
+	Function         = nodes.Function
+	Greater          = nodes.Greater
+	GreaterOrEqual   = nodes.GreaterOrEqual
+	GroupBy          = nodes.GroupBy
+	HasPrefix        = nodes.HasPrefix
+	HasSuffix        = nodes.HasSuffix
+	If               = nodes.If
+	Implies          = nodes.Implies
+	In               = nodes.In
+	Intersect        = nodes.Intersect
+	IsEmpty          = nodes.IsEmpty
+	IsError          = nodes.IsError
+	IsNull           = nodes.IsNull
+	IsSubset         = nodes.IsSubset
+	Last             = nodes.Last
+	Literal          = nodes.Literal
+	Max              = nodes.Max
+	MaxOf            = nodes.MaxOf
+	MedianOf         = nodes.MedianOf
+	Min              = nodes.Min
+	MinOf            = nodes.MinOf
+	Mul              = nodes.Mul
+	Not              = nodes.Not
+	Or               = nodes.Or
+	Overlaps         = nodes.Overlaps
+	PercentileOf     = nodes.PercentileOf
+	Pow              = nodes.Pow
+	Reference        = nodes.Reference
+	Round            = nodes.Round
+	Slice            = nodes.Slice
+	Smaller          = nodes.Smaller
+	SmallerOrEqual   = nodes.SmallerOrEqual
+	Sort             = nodes.Sort
+	SortDesc         = nodes.SortDesc
+	Sqrt             = nodes.Sqrt
+	StddevOf         = nodes.StddevOf
+	Sub              = nodes.Sub
+	Sum              = nodes.Sum
+	SumOf            = nodes.SumOf
+	Switch           = nodes.Switch
+	Try              = nodes.Try
+	Union            = nodes.Union
+	Xor              = nodes.Xor
 )
@@ -10,6 +10,7 @@ import (
 // SortArgs will parse any given keys as required.
 // The required args will be put into the map, and error if any is missing.
 // The rest of the keys found are appended to the slice.
+// Case arguments are not accepted, use SplitCases before sorting them.
 func SortArgs(from []adapters.KeyNode, keys ...string) (map[string]adapters.Node, []adapters.Node, error) {
 	if len(from) < len(keys) {
 		return nil, nil, fmt.Errorf("missing arguments")
@@ -20,6 +21,10 @@ func SortArgs(from []adapters.KeyNode, keys ...string) (map[string]adapters.Node
 
 gotArgLoop:
 	for fromIndex := range from {
+		if from[fromIndex].Case != nil {
+			return nil, nil, fmt.Errorf("unexpected case argument")
+		}
+
 		for keyIndex := range keys {
 			if from[fromIndex].Key == "" || from[fromIndex].Key == keys[keyIndex] {
 				expectedMap[keys[keyIndex]] = from[fromIndex].Node
@@ -32,3 +37,17 @@ gotArgLoop:
 
 	return expectedMap, rest, nil
 }
+
+// SplitCases separates the case arguments, keeping their order, from the other arguments.
+func SplitCases(from []adapters.KeyNode) (cases, rest []adapters.KeyNode) {
+	for i := range from {
+		if from[i].Case != nil {
+			cases = append(cases, from[i])
+			continue
+		}
+
+		rest = append(rest, from[i])
+	}
+
+	return cases, rest
+}
@@ -0,0 +1,68 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type AbsNode struct {
+	value adapters.Node
+}
+
+// Abs defines a node that evaluates to the absolute value of a number, keeping its type.
+// Example: abs(sub(expected, actual)).
+func Abs(value adapters.Node) adapters.Node {
+	if value == nil {
+		return adapters.NodeError{
+			NodeScalar: "abs",
+			Cause:      fmt.Errorf("value cannot be unset"),
+		}
+	}
+
+	return &AbsNode{
+		value: value,
+	}
+}
+
+func (node *AbsNode) Scalar() string {
+	return "abs"
+}
+
+func (node *AbsNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+	}
+}
+
+func (node *AbsNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *AbsNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := absAny(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *AbsNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "value")
+		if err != nil {
+			return nil, err
+		}
+
+		return Abs(orderedArgs["value"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &AbsNode{}
@@ -0,0 +1,84 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Abs(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Abs(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Abs(nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should keep the type of integers", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Abs(nodes.Literal(int64(-3))))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), got)
+	})
+
+	t.Run("should keep the type of floats", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Abs(nodes.Literal(float32(-1.5))))
+		require.NoError(t, err)
+		require.Equal(t, float32(1.5), got)
+	})
+
+	t.Run("should work for decimals", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Abs(nodes.Literal(decimal.MustParse("-12.50"))))
+		require.NoError(t, err)
+		require.Equal(t, decimal.MustParse("12.50"), got)
+	})
+
+	t.Run("should error on overflow", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Abs(nodes.Literal(int8(-128))))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+	})
+
+	t.Run("should error on non numbers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Abs(nodes.Literal("-1")))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Abs_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Abs(nodes.Literal(-1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
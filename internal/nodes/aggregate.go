@@ -0,0 +1,306 @@
+package nodes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type AggregateNode struct {
+	scalar     string
+	collection adapters.Node
+	variable   string
+	projection adapters.Node
+}
+
+// aggregateScalars are the scalars of the aggregate nodes, mapped to their constructors.
+var aggregateScalars = map[string]func(collection adapters.Node, variable string, projection adapters.Node) adapters.Node{
+	"avgOf":    AvgOf,
+	"countOf":  CountOf,
+	"maxOf":    MaxOf,
+	"medianOf": MedianOf,
+	"minOf":    MinOf,
+	"stddevOf": StddevOf,
+	"sumOf":    SumOf,
+}
+
+// SumOf defines a node that sums the elements of a collection, like sum.
+// The projection is evaluated for each element bound to the variable, and is optional together with it.
+// Collections are slices, arrays and maps, whose values are aggregated in the order of their keys.
+// Null collections are empty, and sum to int64(0).
+// Example: sumOf(cart.items, it, it.price).
+func SumOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("sumOf", collection, variable, projection)
+}
+
+// AvgOf defines a node that averages the elements of a collection, or their projections, like avg.
+// Example: avgOf(cart.items, it, it.price).
+func AvgOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("avgOf", collection, variable, projection)
+}
+
+// MinOf defines a node that evaluates to the smallest element of a collection, or of their projections, like min.
+// Example: minOf(cart.items, it, it.price).
+func MinOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("minOf", collection, variable, projection)
+}
+
+// MaxOf defines a node that evaluates to the largest element of a collection, or of their projections, like max.
+// Example: maxOf(cart.items, it, it.price).
+func MaxOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("maxOf", collection, variable, projection)
+}
+
+// CountOf defines a node that counts the elements of a collection as an int64.
+// With a projection, only the elements for which it evaluates to true are counted.
+// Example: countOf(cart.items, it, gt(it.price, 100.0)).
+func CountOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("countOf", collection, variable, projection)
+}
+
+// MedianOf defines a node that evaluates to the median of a collection, or of their projections.
+// Decimals keep their type, while integers and floats return a float64.
+// Example: medianOf(orders, it, it.total).
+func MedianOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("medianOf", collection, variable, projection)
+}
+
+// StddevOf defines a node that evaluates to the population standard deviation of a collection, or of their projections.
+// Decimals keep their type, while integers and floats return a float64.
+// Example: stddevOf(orders, it, it.total).
+func StddevOf(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newAggregate("stddevOf", collection, variable, projection)
+}
+
+func newAggregate(scalar string, collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	if err := validateProjection(collection, variable, projection); err != nil {
+		return adapters.NodeError{
+			NodeScalar: scalar,
+			Cause:      err,
+		}
+	}
+
+	return &AggregateNode{
+		scalar:     scalar,
+		collection: collection,
+		variable:   variable,
+		projection: projection,
+	}
+}
+
+// validateProjection checks the collection is set, and the variable is a plain name set together with the projection.
+func validateProjection(collection adapters.Node, variable string, projection adapters.Node) error {
+	switch {
+	case collection == nil:
+		return fmt.Errorf("collection cannot be unset")
+	case (variable == "") != (projection == nil):
+		return fmt.Errorf("variable and projection should be set together")
+	case strings.Contains(variable, "."):
+		return fmt.Errorf("variable '%s' cannot be a path", variable)
+	default:
+		return nil
+	}
+}
+
+func (node *AggregateNode) Scalar() string {
+	return node.scalar
+}
+
+func (node *AggregateNode) Shape() []adapters.KeyNode {
+	return projectionShape(node.collection, node.variable, node.projection)
+}
+
+// projectionShape returns the collection, followed by the variable as a reference and the projection, when set.
+func projectionShape(collection adapters.Node, variable string, projection adapters.Node) []adapters.KeyNode {
+	kv := []adapters.KeyNode{
+		{Key: "collection", Node: collection},
+	}
+
+	if projection != nil {
+		kv = append(kv,
+			adapters.KeyNode{Key: "variable", Node: Reference(variable)},
+			adapters.KeyNode{Key: "projection", Node: projection},
+		)
+	}
+
+	return kv
+}
+
+func (node *AggregateNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *AggregateNode) Eval(scope adapters.Scope) adapters.Value {
+	values, err := project(scope, node.collection, node.variable, node.projection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := node.aggregate(values)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *AggregateNode) aggregate(values []any) (any, error) {
+	switch node.scalar {
+	case "countOf":
+		return countAny(node.projection != nil, values...)
+	case "sumOf":
+		if len(values) == 0 {
+			return int64(0), nil
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, adapters.ErrEmptyCollection
+	}
+
+	if hasNull(values...) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	switch node.scalar {
+	case "sumOf":
+		return matchingResult(sumAny(values...))
+	case "avgOf":
+		return matchingResult(avgAny(values...))
+	case "minOf":
+		return extremumAny(-1, values...)
+	case "maxOf":
+		return extremumAny(1, values...)
+	case "medianOf":
+		return percentileAny(50, values...)
+	case "stddevOf":
+		return stddevAny(values...)
+	default:
+		return nil, fmt.Errorf("unknown aggregate '%s'", node.scalar)
+	}
+}
+
+// matchingResult converts the result of sumAny and avgAny, which fail when the values don't match.
+func matchingResult(result any, ok bool) (any, error) {
+	if !ok {
+		return nil, adapters.ErrAllNodesMustMatch
+	}
+
+	return result, nil
+}
+
+// countAny counts the values, or only the true ones when they are conditions.
+func countAny(conditions bool, values ...any) (any, error) {
+	if !conditions {
+		return int64(len(values)), nil
+	}
+
+	var count int64
+
+	for _, value := range values {
+		matches, ok := value.(bool)
+		if !ok {
+			return nil, adapters.UnexpectedTypeError{Expected: "bool", Got: value}
+		}
+
+		if matches {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// project evaluates the projection for each element of the collection, bound to the variable.
+// Without a projection, the elements are returned as they are.
+func project(scope adapters.Scope, collection adapters.Node, variable string, projection adapters.Node) ([]any, error) {
+	value, err := scope.Compute(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectElements(scope, elements, variable, projection)
+}
+
+// projectElements returns the projection of each element, without changing the elements.
+func projectElements(scope adapters.Scope, elements []any, variable string, projection adapters.Node) ([]any, error) {
+	projected := slices.Clone(elements)
+	if projection == nil {
+		return projected, nil
+	}
+
+	for i := range elements {
+		value, err := newLocalScope(scope, variable, elements[i]).Compute(projection)
+		if err != nil {
+			return nil, err
+		}
+
+		projected[i] = value
+	}
+
+	return projected, nil
+}
+
+// projectionArgs returns the variable name and the projection from the sorted arguments, if they are set.
+func projectionArgs(orderedArgs map[string]adapters.Node) (string, adapters.Node, error) {
+	variableNode, ok := orderedArgs["variable"]
+	if !ok {
+		return "", orderedArgs["projection"], nil
+	}
+
+	reference, ok := variableNode.(adapters.Typed)
+	if !ok || reference.Type() != adapters.NodeTypeReference {
+		return "", nil, fmt.Errorf("variable should be a name, like it")
+	}
+
+	return variableNode.Scalar(), orderedArgs["projection"], nil
+}
+
+// sortProjectionArgs sorts the collection, and the optional variable and projection.
+func sortProjectionArgs(args []adapters.KeyNode) (adapters.Node, string, adapters.Node, error) {
+	keys := []string{"collection", "variable", "projection"}
+
+	orderedArgs, rest, err := gonutils.SortArgs(args, keys[:min(len(args), len(keys))]...)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, "", nil, fmt.Errorf("unexpected arguments, expected collection, variable and projection")
+	}
+
+	variable, projection, err := projectionArgs(orderedArgs)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return orderedArgs["collection"], variable, projection, nil
+}
+
+func (node *AggregateNode) Register(codex adapters.Codex) error {
+	for scalar, constructor := range aggregateScalars {
+		err := codex.Register(scalar, func(args []adapters.KeyNode) (adapters.Node, error) {
+			collection, variable, projection, err := sortProjectionArgs(args)
+			if err != nil {
+				return nil, err
+			}
+
+			return constructor(collection, variable, projection), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var _ adapters.SerializableNode = &AggregateNode{}
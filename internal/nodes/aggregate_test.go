@@ -0,0 +1,179 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cartItem struct {
+	Name  string  `gon:"name"`
+	Price float64 `gon:"price"`
+	Gift  bool    `gon:"gift"`
+}
+
+func Test_Aggregate(t *testing.T) {
+	scope, err := gon.NewScope().
+		WithValues(gon.Values{
+			"cart": gon.Literal(map[string]any{
+				"items": []cartItem{
+					{Name: "book", Price: 12.5},
+					{Name: "pen", Price: 2.5, Gift: true},
+					{Name: "lamp", Price: 30},
+				},
+			}),
+			"prices": gon.Literal(map[string]decimal.Decimal{
+				"b": decimal.MustParse("2.50"),
+				"a": decimal.MustParse("12.50"),
+			}),
+			"numbers": gon.Literal([3]int{4, 1, 7}),
+			"empty":   gon.Literal([]int{}),
+			"minimum": gon.Literal(10.0),
+		})
+	require.NoError(t, err)
+
+	items := nodes.Reference("cart.items")
+
+	t.Run("should not have unset collection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.SumOf(nil, "", nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should set the variable with the projection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.SumOf(items, "it", nil))
+		require.Error(t, err)
+
+		_, err = scope.Compute(nodes.SumOf(items, "it.price", nodes.Reference("it")))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.SumOf(nodes.Literal(assert.AnError), "", nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+
+		_, err = scope.Compute(nodes.SumOf(items, "it", nodes.Reference("it.missing")))
+		require.ErrorAs(t, err, &adapters.DefinitionNotFoundError{})
+	})
+
+	t.Run("should aggregate projections", func(t *testing.T) {
+		testCases := []struct {
+			node     adapters.Node
+			expected any
+		}{
+			{nodes.SumOf(items, "it", nodes.Reference("it.price")), 45.0},
+			{nodes.AvgOf(items, "it", nodes.Reference("it.price")), 15.0},
+			{nodes.MinOf(items, "it", nodes.Reference("it.price")), 2.5},
+			{nodes.MaxOf(items, "it", nodes.Reference("it.price")), 30.0},
+			{nodes.MedianOf(items, "it", nodes.Reference("it.price")), 12.5},
+			{nodes.CountOf(items, "", nil), int64(3)},
+			{nodes.CountOf(items, "it", nodes.Reference("it.gift")), int64(1)},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.node.Scalar(), func(t *testing.T) {
+				got, err := scope.Compute(tc.node)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, got)
+			})
+		}
+	})
+
+	t.Run("should resolve other definitions from the projection", func(t *testing.T) {
+		condition := nodes.GreaterOrEqual(nodes.Reference("it.price"), nodes.Reference("minimum"))
+
+		got, err := scope.Compute(nodes.CountOf(items, "it", condition))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), got)
+	})
+
+	t.Run("should aggregate arrays and map values", func(t *testing.T) {
+		got, err := scope.Compute(nodes.SumOf(nodes.Reference("numbers"), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, 12, got)
+
+		got, err = scope.Compute(nodes.SumOf(nodes.Reference("prices"), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, "15.00", got.(decimal.Decimal).String())
+	})
+
+	t.Run("should compute statistics", func(t *testing.T) {
+		got, err := scope.Compute(nodes.MedianOf(nodes.Reference("numbers"), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, 4.0, got)
+
+		got, err = scope.Compute(nodes.StddevOf(nodes.Literal([]int{2, 4, 4, 4, 5, 5, 7, 9}), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, 2.0, got)
+
+		got, err = scope.Compute(nodes.MedianOf(nodes.Reference("prices"), "", nil))
+		require.NoError(t, err)
+		require.Zero(t, got.(decimal.Decimal).Cmp(decimal.MustParse("7.5")))
+	})
+
+	t.Run("should handle empty collections", func(t *testing.T) {
+		got, err := scope.Compute(nodes.SumOf(nodes.Reference("empty"), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), got)
+
+		got, err = scope.Compute(nodes.CountOf(nodes.Literal(nil), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), got)
+
+		_, err = scope.Compute(nodes.AvgOf(nodes.Reference("empty"), "", nil))
+		require.ErrorIs(t, err, adapters.ErrEmptyCollection)
+	})
+
+	t.Run("should error on invalid elements", func(t *testing.T) {
+		_, err := scope.Compute(nodes.SumOf(nodes.Literal("items"), "", nil))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+
+		_, err = scope.Compute(nodes.CountOf(items, "it", nodes.Reference("it.name")))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+
+		_, err = scope.Compute(nodes.MaxOf(nodes.Literal([]any{1, nil}), "", nil))
+		require.ErrorIs(t, err, adapters.ErrNullOperand)
+	})
+}
+
+func Test_Aggregate_Encoding(t *testing.T) {
+	t.Run("should decode with projection", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.SumOf(nodes.Reference("cart.items"), "it", nodes.Reference("it.price"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+
+	t.Run("should round-trip through the human encoding", func(t *testing.T) {
+		rule := "countOf(cart.items, it, gt(it.price, 10.0))\n"
+
+		got, err := encoding.Format([]byte(rule), encoding.DefaultExpressionCodex, encoding.Unnamed())
+		require.NoError(t, err)
+		require.Equal(t, rule, string(got))
+	})
+}
@@ -0,0 +1,80 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+	"github.com/sonalys/gon/internal/sliceutils"
+)
+
+type AndNode struct {
+	nodes []adapters.Node
+}
+
+// And defines an and node, there must be at least one input.
+// It returns the value of the first error, false boolean or non-boolean expression.
+func And(nodes ...adapters.Node) adapters.Node {
+	if len(nodes) == 0 {
+		return adapters.NodeError{
+			NodeScalar: "and",
+			Cause:      adapters.ErrMustHaveArguments,
+		}
+	}
+
+	for i := range nodes {
+		if nodes[i] == nil {
+			return adapters.NodeError{
+				NodeScalar: "and",
+				Cause:      adapters.ErrAllNodesMustBeSet,
+			}
+		}
+	}
+
+	return &AndNode{
+		nodes: nodes,
+	}
+}
+
+func (node *AndNode) Scalar() string {
+	return "and"
+}
+
+func (node *AndNode) Shape() []adapters.KeyNode {
+	return sliceutils.Map(node.nodes, func(from adapters.Node) adapters.KeyNode { return adapters.KeyNode{Node: from} })
+}
+
+func (node *AndNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *AndNode) Eval(scope adapters.Scope) adapters.Value {
+	for _, expr := range node.nodes {
+		value, err := scope.Compute(expr)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		switch value := value.(type) {
+		case bool:
+			if !value {
+				return Literal(false)
+			}
+		default:
+			return Literal(value)
+		}
+	}
+
+	return Literal(true)
+}
+
+func (node *AndNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		_, argsSlice, err := gonutils.SortArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return And(argsSlice...), nil
+	})
+}
+
+var _ adapters.SerializableNode = &AndNode{}
@@ -56,6 +56,10 @@ func (node *AvgNode) Eval(scope adapters.Scope) adapters.Value {
 		values = append(values, curValue)
 	}
 
+	if hasNull(values...) {
+		return adapters.NewNodeError(node, adapters.ErrNullOperand)
+	}
+
 	sum, ok := avgAny(values...)
 	if !ok {
 		return adapters.NewNodeError(node, adapters.ErrAllNodesMustMatch)
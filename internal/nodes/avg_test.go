@@ -5,6 +5,7 @@ import (
 
 	"github.com/sonalys/gon"
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
 	"github.com/sonalys/gon/encoding"
 	"github.com/sonalys/gon/internal/nodes"
 	"github.com/stretchr/testify/assert"
@@ -42,6 +43,14 @@ func Test_Avg(t *testing.T) {
 		require.ErrorAs(t, err, &adapters.NodeError{})
 	})
 
+	t.Run("should average decimals", func(t *testing.T) {
+		expr := nodes.Avg(nodes.Literal(decimal.MustParse("10.00")), nodes.Literal(decimal.MustParse("0.01")))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "5.005", got.(decimal.Decimal).String())
+	})
+
 	t.Run("should average values", func(t *testing.T) {
 		expr := nodes.Avg(nodes.Literal(1), nodes.Literal(3))
 
@@ -11,14 +11,20 @@ type (
 	CallNode struct {
 		funcName string
 		argNodes []adapters.Node
+		keywords []adapters.KeyNode
 	}
 )
 
 // Call defines a function call.
 // It tries to find the provided funcName under it's evaluated scope and call it with the given args.
 // It will evaluate all args before providing them to the funcName.
-// Returns a NodeError if the funcName is not found, callable or has wrong arguments.
+// Returns a NodeError if the funcName is not found or callable.
+// Argument errors are given to the function as values, and failures of the function itself,
+// including wrong arguments and returned errors, are wrapped in a CallError.
 // Context doesn't need to be given as an argument, and is handled automatically by nodes.
+// Arguments are converted to the function parameters, including int64 to int and the elements of variadic parameters.
+// A non-nil trailing error result fails the call, and multiple results are returned as a []any.
+// Results can be selected with the collection nodes, like first(call("pair")) or slice(call("pair"), 1, 2).
 func Call(funcName string, argNodes ...adapters.Node) adapters.Node {
 	return &CallNode{
 		funcName: funcName,
@@ -26,12 +32,32 @@ func Call(funcName string, argNodes ...adapters.Node) adapters.Node {
 	}
 }
 
+// CallWithKeywords defines a function call with keyword arguments.
+// Keywords fill the fields of the last function parameter, a struct or struct pointer, by their gon tags.
+// Example: call("discount", order.total, rate: 0.1).
+func CallWithKeywords(funcName string, argNodes []adapters.Node, keywords ...adapters.KeyNode) adapters.Node {
+	for _, keyword := range keywords {
+		if keyword.Key == "" || keyword.Case != nil || keyword.Node == nil {
+			return adapters.NodeError{
+				NodeScalar: "call",
+				Cause:      fmt.Errorf("keyword arguments must have a key and a value"),
+			}
+		}
+	}
+
+	return &CallNode{
+		funcName: funcName,
+		argNodes: argNodes,
+		keywords: keywords,
+	}
+}
+
 func (node *CallNode) Scalar() string {
 	return "call"
 }
 
 func (node *CallNode) Shape() []adapters.KeyNode {
-	kv := make([]adapters.KeyNode, 0, len(node.argNodes)+1)
+	kv := make([]adapters.KeyNode, 0, len(node.argNodes)+len(node.keywords)+1)
 	kv = append(kv,
 		adapters.KeyNode{Key: "", Node: Literal(node.funcName)},
 	)
@@ -42,7 +68,7 @@ func (node *CallNode) Shape() []adapters.KeyNode {
 		)
 	}
 
-	return kv
+	return append(kv, node.keywords...)
 }
 
 func (node *CallNode) Type() adapters.NodeType {
@@ -56,8 +82,27 @@ func (node *CallNode) Eval(scope adapters.Scope) adapters.Value {
 		values = append(values, node.argNodes[i].Eval(scope))
 	}
 
+	if len(node.keywords) > 0 {
+		keywords := make(adapters.KeywordArgs, len(node.keywords))
+
+		for _, keyword := range node.keywords {
+			value, err := scope.Compute(keyword.Node)
+			if err != nil {
+				return adapters.NewNodeError(node, err)
+			}
+
+			keywords[keyword.Key] = value
+		}
+
+		values = append(values, Literal(keywords))
+	}
+
 	definition, ok := scope.Definition(node.funcName)
 	if !ok {
+		if err, ok := providerError(definition); ok {
+			return adapters.NewNodeError(node, err)
+		}
+
 		return adapters.NewNodeError(node, adapters.DefinitionNotFoundError{
 			DefinitionKey: node.funcName,
 		})
@@ -70,7 +115,15 @@ func (node *CallNode) Eval(scope adapters.Scope) adapters.Value {
 		})
 	}
 
-	return callable.Call(scope, node.funcName, values...)
+	result := callable.Call(scope, node.funcName, values...)
+	if err, ok := result.Value().(error); ok {
+		return adapters.NewNodeError(node, adapters.CallError{
+			FuncName: node.funcName,
+			Cause:    err,
+		})
+	}
+
+	return result
 }
 
 func (node *CallNode) Register(codex adapters.Codex) error {
@@ -88,10 +141,29 @@ func (node *CallNode) Register(codex adapters.Codex) error {
 			return Call(funcName), nil
 		}
 
-		transformedArgs := sliceutils.Map(args[1:], expressionTransform)
+		positional, keywords := splitKeywords(args[1:])
+		transformedArgs := sliceutils.Map(positional, expressionTransform)
+
+		if len(keywords) == 0 {
+			return Call(funcName, transformedArgs...), nil
+		}
 
-		return Call(funcName, transformedArgs...), nil
+		return CallWithKeywords(funcName, transformedArgs, keywords...), nil
 	})
 }
 
+// splitKeywords separates the keyed arguments from the positional ones.
+func splitKeywords(args []adapters.KeyNode) (positional, keywords []adapters.KeyNode) {
+	for _, arg := range args {
+		if arg.Key == "" && arg.Case == nil {
+			positional = append(positional, arg)
+			continue
+		}
+
+		keywords = append(keywords, arg)
+	}
+
+	return positional, keywords
+}
+
 var _ adapters.SerializableNode = &CallNode{}
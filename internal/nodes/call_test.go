@@ -2,6 +2,10 @@ package nodes_test
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/sonalys/gon"
@@ -45,6 +49,46 @@ func Test_Call(t *testing.T) {
 		require.ErrorAs(t, err, &target)
 	})
 
+	t.Run("should wrap function errors", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"fail": nodes.Literal(func() error {
+					return assert.AnError
+				}),
+			})
+		require.NoError(t, err)
+
+		_, err = scope.Compute(nodes.Call("fail"))
+
+		var target adapters.CallError
+		require.ErrorAs(t, err, &target)
+		require.Equal(t, "fail", target.FuncName)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should give argument errors to the function", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"isError": nodes.Literal(func(value any) bool {
+					_, ok := value.(error)
+					return ok
+				}),
+				"double": nodes.Literal(func(value int) int {
+					return value * 2
+				}),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.Call("isError", nodes.Reference("missing")))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+
+		_, err = scope.Compute(nodes.Call("double", nodes.Reference("missing")))
+		require.ErrorAs(t, err, &adapters.CallError{})
+	})
+
 	t.Run("should call func without context", func(t *testing.T) {
 		scope, err := gon.
 			NewScope().
@@ -77,6 +121,142 @@ func Test_Call(t *testing.T) {
 		got := node.Eval(scope).Value()
 		require.Equal(t, 5, got)
 	})
+
+	t.Run("should convert arguments", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"double": nodes.Literal(func(value int) int {
+					return value * 2
+				}),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.Call("double", gon.Int(4)))
+		require.NoError(t, err)
+		require.Equal(t, 8, got)
+	})
+
+	t.Run("should error on unconvertible arguments", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"double": nodes.Literal(func(value int) int {
+					return value * 2
+				}),
+			})
+		require.NoError(t, err)
+
+		_, err = scope.Compute(nodes.Call("double", nodes.Literal("4")))
+		require.ErrorAs(t, err, &adapters.TypeConversionError{})
+	})
+
+	t.Run("should call variadic func", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"join": nodes.Literal(func(separator string, values ...int) string {
+					return fmt.Sprint(separator, values)
+				}),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.Call("join", nodes.Literal(","), gon.Int(1), gon.Int(2)))
+		require.NoError(t, err)
+		require.Equal(t, ",[1 2]", got)
+
+		got, err = scope.Compute(nodes.Call("join", nodes.Literal(",")))
+		require.NoError(t, err)
+		require.Equal(t, ",[]", got)
+
+		_, err = scope.Compute(nodes.Call("join"))
+		require.ErrorAs(t, err, &adapters.CallError{})
+	})
+
+	t.Run("should not give context to empty interfaces", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"count": nodes.Literal(func(values ...any) int {
+					return len(values)
+				}),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.Call("count", nodes.Literal(1), nodes.Literal("a")))
+		require.NoError(t, err)
+		require.Equal(t, 2, got)
+	})
+
+	t.Run("should return the value of (T, error) results", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"parse": nodes.Literal(strconv.Atoi),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.Call("parse", nodes.Literal("42")))
+		require.NoError(t, err)
+		require.Equal(t, 42, got)
+
+		_, err = scope.Compute(nodes.Call("parse", nodes.Literal("a")))
+		require.ErrorAs(t, err, &adapters.CallError{})
+		require.ErrorAs(t, err, new(*strconv.NumError))
+	})
+
+	t.Run("should return multiple results as a slice", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"divide": nodes.Literal(func(a, b int) (int, int, error) {
+					return a / b, a % b, nil
+				}),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.Call("divide", gon.Int(7), gon.Int(2)))
+		require.NoError(t, err)
+		require.Equal(t, []any{3, 1}, got)
+
+		got, err = scope.Compute(nodes.Last(nodes.Call("divide", gon.Int(7), gon.Int(2))))
+		require.NoError(t, err)
+		require.Equal(t, 1, got)
+	})
+
+	t.Run("should fill keyword arguments", func(t *testing.T) {
+		type options struct {
+			Rate  float64 `gon:"rate"`
+			Round bool    `gon:"round"`
+		}
+
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"discount": nodes.Literal(func(total float64, opts *options) float64 {
+					if opts.Round {
+						return math.Round(total * opts.Rate)
+					}
+
+					return total * opts.Rate
+				}),
+			})
+		require.NoError(t, err)
+
+		got, err := scope.Compute(nodes.CallWithKeywords("discount",
+			[]adapters.Node{gon.Float(25)},
+			adapters.KeyNode{Key: "rate", Node: gon.Float(0.1)},
+			adapters.KeyNode{Key: "round", Node: gon.Bool(true)},
+		))
+		require.NoError(t, err)
+		require.Equal(t, 3.0, got)
+
+		_, err = scope.Compute(nodes.CallWithKeywords("discount",
+			[]adapters.Node{gon.Float(25)},
+			adapters.KeyNode{Key: "unknown", Node: gon.Float(0.1)},
+		))
+		require.ErrorAs(t, err, &adapters.CallError{})
+	})
 }
 
 func Test_Call_Encoding(t *testing.T) {
@@ -133,4 +313,14 @@ func Test_Call_Encoding(t *testing.T) {
 			require.Equal(t, node, got)
 		})
 	})
+
+	t.Run("should decode keyword arguments", func(t *testing.T) {
+		node, err := encoding.Decode([]byte(`call("funcName", 1, rate: 0.1)`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		err = encoding.HumanEncode(&buf, node, encoding.Compact(), encoding.Unnamed())
+		require.NoError(t, err)
+		require.Equal(t, `call("funcName",1,rate: 0.1)`, buf.String())
+	})
 }
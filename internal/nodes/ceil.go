@@ -0,0 +1,70 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type CeilNode struct {
+	value adapters.Node
+}
+
+// Ceil defines a node that rounds a number towards positive infinity, keeping its type.
+// Floats are rounded by their shortest decimal representation, like round, and integers are kept as they are.
+// Example: ceil(div(order.weight, 0.5)).
+func Ceil(value adapters.Node) adapters.Node {
+	if value == nil {
+		return adapters.NodeError{
+			NodeScalar: "ceil",
+			Cause:      fmt.Errorf("value cannot be unset"),
+		}
+	}
+
+	return &CeilNode{
+		value: value,
+	}
+}
+
+func (node *CeilNode) Scalar() string {
+	return "ceil"
+}
+
+func (node *CeilNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+	}
+}
+
+func (node *CeilNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *CeilNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := roundAny(value, 0, decimal.Ceiling)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *CeilNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "value")
+		if err != nil {
+			return nil, err
+		}
+
+		return Ceil(orderedArgs["value"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &CeilNode{}
@@ -0,0 +1,79 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Ceil(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Ceil(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Ceil(nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should round floats up", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Ceil(nodes.Literal(-1.5)))
+		require.NoError(t, err)
+		require.Equal(t, -1.0, got)
+	})
+
+	t.Run("should keep integers", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Ceil(nodes.Literal(int64(3))))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), got)
+	})
+
+	t.Run("should work for decimals", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Ceil(nodes.Literal(decimal.MustParse("12.01"))))
+		require.NoError(t, err)
+		require.Equal(t, decimal.MustParse("13"), got)
+	})
+
+	t.Run("should error on non numbers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Ceil(nodes.Literal(true)))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Ceil_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Ceil(nodes.Literal(1.5))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
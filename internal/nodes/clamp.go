@@ -0,0 +1,83 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type ClampNode struct {
+	value adapters.Node
+	min   adapters.Node
+	max   adapters.Node
+}
+
+// Clamp defines a node that limits the value to the range from min to max, inclusive.
+// Values are compared like min and max, and the range fails if min is greater than max.
+// Example: clamp(score, 0, 100).
+func Clamp(value, min, max adapters.Node) adapters.Node {
+	if value == nil || min == nil || max == nil {
+		return adapters.NodeError{
+			NodeScalar: "clamp",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &ClampNode{
+		value: value,
+		min:   min,
+		max:   max,
+	}
+}
+
+func (node *ClampNode) Scalar() string {
+	return "clamp"
+}
+
+func (node *ClampNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+		{Key: "min", Node: node.min},
+		{Key: "max", Node: node.max},
+	}
+}
+
+func (node *ClampNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *ClampNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	lower, err := scope.Compute(node.min)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	upper, err := scope.Compute(node.max)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	clamped, err := clampAny(value, lower, upper)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(clamped)
+}
+
+func (node *ClampNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "value", "min", "max")
+		if err != nil {
+			return nil, err
+		}
+
+		return Clamp(orderedArgs["value"], orderedArgs["min"], orderedArgs["max"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &ClampNode{}
@@ -0,0 +1,83 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Clamp(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Clamp(nodes.Literal(1), nil, nodes.Literal(2)))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Clamp(nodes.Literal(assert.AnError), nodes.Literal(1), nodes.Literal(2)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should keep values in range", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Clamp(nodes.Literal(5), nodes.Literal(0), nodes.Literal(10)))
+		require.NoError(t, err)
+		require.Equal(t, 5, got)
+	})
+
+	t.Run("should limit to min", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Clamp(nodes.Literal(-5), nodes.Literal(0), nodes.Literal(10)))
+		require.NoError(t, err)
+		require.Equal(t, 0, got)
+	})
+
+	t.Run("should limit to max", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Clamp(nodes.Literal(15), nodes.Literal(0), nodes.Literal(10)))
+		require.NoError(t, err)
+		require.Equal(t, 10, got)
+	})
+
+	t.Run("should error on inverted range", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Clamp(nodes.Literal(5), nodes.Literal(10), nodes.Literal(0)))
+		require.ErrorIs(t, err, adapters.ErrInvalidRange)
+	})
+
+	t.Run("should error on mixed types", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Clamp(nodes.Literal(5.0), nodes.Literal(0), nodes.Literal(10)))
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+	})
+}
+
+func Test_Clamp_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Clamp(nodes.Literal(5), nodes.Literal(0), nodes.Literal(10))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type CondNode struct {
+	cases         []adapters.KeyNode
+	defaultBranch adapters.Node
+}
+
+// Cond defines a multi-way branching node, with a condition for each case.
+// It evaluates the result of the first case with a true condition, or the default result when none is true.
+// Conditions should evaluate to bool.
+// Example: cond(gt(x, 100): "high", gt(x, 10): "mid", default: "low").
+func Cond(cases ...adapters.KeyNode) adapters.Node {
+	caseArgs, defaultBranch, err := splitDefault(cases)
+	if err != nil {
+		return adapters.NodeError{
+			NodeScalar: "cond",
+			Cause:      err,
+		}
+	}
+
+	if len(caseArgs) == 0 {
+		return adapters.NodeError{
+			NodeScalar: "cond",
+			Cause:      adapters.ErrMustHaveArguments,
+		}
+	}
+
+	return &CondNode{
+		cases:         caseArgs,
+		defaultBranch: defaultBranch,
+	}
+}
+
+func (node *CondNode) Scalar() string {
+	return "cond"
+}
+
+func (node *CondNode) Shape() []adapters.KeyNode {
+	kv := make([]adapters.KeyNode, 0, len(node.cases)+1)
+	kv = append(kv, node.cases...)
+
+	if node.defaultBranch != nil {
+		kv = append(kv, Default(node.defaultBranch))
+	}
+
+	return kv
+}
+
+func (node *CondNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *CondNode) Eval(scope adapters.Scope) adapters.Value {
+	for _, arg := range node.cases {
+		value, err := scope.Compute(arg.Case)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		fulfilled, ok := value.(bool)
+		if !ok {
+			return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "bool", Got: value})
+		}
+
+		if fulfilled {
+			return evalBranch(node, scope, arg.Node)
+		}
+	}
+
+	if node.defaultBranch != nil {
+		return evalBranch(node, scope, node.defaultBranch)
+	}
+
+	return adapters.NewNodeError(node, fmt.Errorf("no case matched"))
+}
+
+func (node *CondNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		cases, rest := gonutils.SplitCases(args)
+
+		if err := checkBranchKeys(rest); err != nil {
+			return nil, err
+		}
+
+		_, defaults, err := gonutils.SortArgs(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(defaults) > 1 {
+			return nil, fmt.Errorf("only one default can be set")
+		}
+
+		for _, defaultBranch := range defaults {
+			cases = append(cases, Default(defaultBranch))
+		}
+
+		return Cond(cases...), nil
+	})
+}
+
+var _ adapters.SerializableNode = &CondNode{}
@@ -0,0 +1,138 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cond(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should error without cases", func(t *testing.T) {
+		expr := nodes.Cond(nodes.Default(nodes.Literal(1)))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, adapters.ErrMustHaveArguments)
+	})
+
+	t.Run("should compute the first true case", func(t *testing.T) {
+		expr := nodes.Cond(
+			nodes.Case(nodes.Literal(false), nodes.Literal("high")),
+			nodes.Case(nodes.Literal(true), nodes.Literal("mid")),
+			nodes.Case(nodes.Literal(true), nodes.Literal("low")),
+		)
+
+		value, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "mid", value)
+	})
+
+	t.Run("should compute default if no case is true", func(t *testing.T) {
+		expr := nodes.Cond(
+			nodes.Case(nodes.Literal(false), nodes.Literal("high")),
+			nodes.Default(nodes.Literal("low")),
+		)
+
+		value, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "low", value)
+	})
+
+	t.Run("should not compute cases after the first true case", func(t *testing.T) {
+		expr := nodes.Cond(
+			nodes.Case(nodes.Literal(true), nodes.Literal("high")),
+			nodes.Case(nodes.Literal(assert.AnError), nodes.Literal("mid")),
+		)
+
+		value, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "high", value)
+	})
+
+	t.Run("should error if condition doesn't return a bool", func(t *testing.T) {
+		expr := nodes.Cond(nodes.Case(nodes.Literal(1), nodes.Literal("high")))
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should error if no case is true without default", func(t *testing.T) {
+		expr := nodes.Cond(nodes.Case(nodes.Literal(false), nodes.Literal("high")))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should propagate result error", func(t *testing.T) {
+		expr := nodes.Cond(nodes.Case(nodes.Literal(true), nodes.Literal(assert.AnError)))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+}
+
+func Test_Cond_Encoding(t *testing.T) {
+	t.Run("should decode with cases and default", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Cond(
+				nodes.Case(nodes.Greater(nodes.Reference("amount"), nodes.Literal(100)), nodes.Literal("high")),
+				nodes.Default(nodes.Literal("low")),
+			)
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+
+	t.Run("should decode references as conditions", func(t *testing.T) {
+		node, err := encoding.Decode([]byte(`cond(user.vip: "yes", default: "no")`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		scope, err := gon.NewScope().WithValues(gon.Values{"user": nodes.Literal(map[string]any{"vip": true})})
+		require.NoError(t, err)
+
+		value, err := scope.Compute(node)
+		require.NoError(t, err)
+		require.Equal(t, "yes", value)
+	})
+
+	t.Run("should reject unknown named arguments", func(t *testing.T) {
+		codex := make(encoding.Codex)
+		require.NoError(t, (&nodes.CondNode{}).Register(&codex))
+
+		_, err := codex["cond"]([]adapters.KeyNode{
+			nodes.Case(nodes.Reference("flag"), nodes.Literal(1)),
+			{Key: "foo", Node: nodes.Literal(0)},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("should reject case arguments in other nodes", func(t *testing.T) {
+		_, err := encoding.Decode([]byte(`not(true: false)`), encoding.DefaultExpressionCodex)
+		require.Error(t, err)
+	})
+}
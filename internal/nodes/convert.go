@@ -0,0 +1,95 @@
+package nodes
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/sonalys/gon/decimal"
+)
+
+// ConvertValue converts the value to the target type.
+// Values assignable to the target are kept as they are.
+// Numbers convert to any float type, and to integer types when they are representable without losing precision.
+// Integers also convert to decimals, and decimals to floats.
+func ConvertValue(value any, target reflect.Type) (reflect.Value, bool) {
+	result := reflect.New(target).Elem()
+
+	if value == nil {
+		switch target.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return result, true
+		default:
+			return result, false
+		}
+	}
+
+	valueOf := reflect.ValueOf(value)
+
+	if valueOf.Type().AssignableTo(target) {
+		result.Set(valueOf)
+		return result, true
+	}
+
+	if d, ok := value.(decimal.Decimal); ok && isFloat(target.Kind()) {
+		result.Set(reflect.ValueOf(d.Float64()).Convert(target))
+		return result, true
+	}
+
+	if target == reflect.TypeFor[decimal.Decimal]() {
+		switch {
+		case valueOf.CanInt():
+			result.Set(reflect.ValueOf(decimal.FromInt(valueOf.Int())))
+			return result, true
+		case valueOf.CanUint() && valueOf.Uint() <= math.MaxInt64:
+			result.Set(reflect.ValueOf(decimal.FromInt(int64(valueOf.Uint()))))
+			return result, true
+		default:
+			return result, false
+		}
+	}
+
+	if !isNumber(valueOf.Kind()) || !isNumber(target.Kind()) {
+		return result, false
+	}
+
+	converted := valueOf.Convert(target)
+
+	if !isFloat(target.Kind()) {
+		// Integers must keep their value and sign, failing on fractions and out of range values.
+		if isFloat(valueOf.Kind()) && math.Trunc(valueOf.Float()) != valueOf.Float() {
+			return result, false
+		}
+
+		if isNegative(valueOf) != isNegative(converted) || converted.Convert(valueOf.Type()).Interface() != value {
+			return result, false
+		}
+	}
+
+	result.Set(converted)
+
+	return result, true
+}
+
+func isNegative(value reflect.Value) bool {
+	switch {
+	case value.CanInt():
+		return value.Int() < 0
+	case value.CanFloat():
+		return value.Float() < 0
+	default:
+		return false
+	}
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isSigned(kind) || isFloat(kind) ||
+		reflect.Uint <= kind && kind <= reflect.Uintptr
+}
+
+func isSigned(kind reflect.Kind) bool {
+	return reflect.Int <= kind && kind <= reflect.Int64
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
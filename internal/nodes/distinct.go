@@ -0,0 +1,105 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+)
+
+type DistinctNode struct {
+	collection adapters.Node
+	variable   string
+	projection adapters.Node
+}
+
+// Distinct defines a node that removes the duplicated elements of a collection into a new slice, keeping their first occurrence.
+// Elements, or their projections, are compared like equal.
+// Example: distinct(cart.items, it, it.category).
+func Distinct(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	if err := validateProjection(collection, variable, projection); err != nil {
+		return adapters.NodeError{
+			NodeScalar: "distinct",
+			Cause:      err,
+		}
+	}
+
+	return &DistinctNode{
+		collection: collection,
+		variable:   variable,
+		projection: projection,
+	}
+}
+
+func (node *DistinctNode) Scalar() string {
+	return "distinct"
+}
+
+func (node *DistinctNode) Shape() []adapters.KeyNode {
+	return projectionShape(node.collection, node.variable, node.projection)
+}
+
+func (node *DistinctNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *DistinctNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	keys, err := projectElements(scope, elements, node.variable, node.projection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	distinct := make([]any, 0, len(elements))
+	distinctKeys := make([]any, 0, len(keys))
+
+	for i := range elements {
+		index, err := indexOf(keys[i], distinctKeys)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		if index < 0 {
+			distinct = append(distinct, elements[i])
+			distinctKeys = append(distinctKeys, keys[i])
+		}
+	}
+
+	return Literal(distinct)
+}
+
+// indexOf returns the index of the first element equal to the value, or -1.
+// Values are compared like equalAny, and fail if they can't be compared.
+func indexOf(value any, elements []any) (int, error) {
+	for i := range elements {
+		equal, ok := equalAny(value, elements[i])
+		if !ok {
+			return -1, adapters.IncompatiblePairError{First: value, Second: elements[i]}
+		}
+
+		if equal {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+func (node *DistinctNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		collection, variable, projection, err := sortProjectionArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return Distinct(collection, variable, projection), nil
+	})
+}
+
+var _ adapters.SerializableNode = &DistinctNode{}
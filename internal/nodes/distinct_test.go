@@ -0,0 +1,74 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Distinct(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset collection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Distinct(nil, "", nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Distinct(nodes.Literal(assert.AnError), "", nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should keep the first occurrences", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Distinct(nodes.Literal([]any{"a", "b", "a", nil, "c", nil}), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, []any{"a", "b", nil, "c"}, got)
+	})
+
+	t.Run("should compare the projections", func(t *testing.T) {
+		items := nodes.Literal([]cartItem{{Name: "book", Price: 2}, {Name: "pen", Price: 1}, {Name: "mug", Price: 2}})
+
+		got, err := scope.Compute(nodes.Distinct(items, "it", nodes.Reference("it.price")))
+		require.NoError(t, err)
+		require.Equal(t, []any{cartItem{Name: "book", Price: 2}, cartItem{Name: "pen", Price: 1}}, got)
+	})
+
+	t.Run("should error on mixed types", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Distinct(nodes.Literal([]any{1, "a"}), "", nil))
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+	})
+}
+
+func Test_Distinct_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Distinct(nodes.Reference("cart.items"), "it", nodes.Reference("it.category"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
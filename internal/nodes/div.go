@@ -0,0 +1,75 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type DivNode struct {
+	first  adapters.Node
+	second adapters.Node
+}
+
+// Div defines a division node, all input nodes should evaluate to the same numeric type.
+// Returns the first value divided by the second, failing on division by zero. Integers use integer division.
+// Example: div(order.total, order.items).
+func Div(first, second adapters.Node) adapters.Node {
+	if first == nil || second == nil {
+		return adapters.NodeError{
+			NodeScalar: "div",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &DivNode{
+		first:  first,
+		second: second,
+	}
+}
+
+func (node *DivNode) Scalar() string {
+	return "div"
+}
+
+func (node *DivNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "first", Node: node.first},
+		{Key: "second", Node: node.second},
+	}
+}
+
+func (node *DivNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *DivNode) Eval(scope adapters.Scope) adapters.Value {
+	firstValue, err := scope.Compute(node.first)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	secondValue, err := scope.Compute(node.second)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	quotient, err := arithmeticAny('/', firstValue, secondValue)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(quotient)
+}
+
+func (node *DivNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "first", "second")
+		if err != nil {
+			return nil, err
+		}
+
+		return Div(orderedArgs["first"], orderedArgs["second"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &DivNode{}
@@ -0,0 +1,97 @@
+package nodes_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Div(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		expr := nodes.Div(nil, nodes.Literal(1))
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should error on division by zero", func(t *testing.T) {
+		expr := nodes.Div(nodes.Literal(1), nodes.Literal(0))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, adapters.ErrDivisionByZero)
+	})
+
+	t.Run("should use integer division", func(t *testing.T) {
+		expr := nodes.Div(nodes.Literal(7), nodes.Literal(2))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 3, got)
+	})
+
+	t.Run("should error on integer overflow", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Div(nodes.Literal(int64(math.MinInt64)), nodes.Literal(int64(-1))))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		got, err := scope.Compute(nodes.Div(nodes.Literal(int64(math.MinInt64)), nodes.Literal(int64(-2))))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64/-2), got)
+	})
+
+	t.Run("should divide decimals", func(t *testing.T) {
+		expr := nodes.Div(nodes.Literal(decimal.MustParse("10.00")), nodes.Literal(int64(4)))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "2.50", got.(decimal.Decimal).String())
+
+		_, err = scope.Compute(nodes.Div(nodes.Literal(decimal.MustParse("1")), nodes.Literal(decimal.Decimal{})))
+		require.ErrorIs(t, err, adapters.ErrDivisionByZero)
+	})
+
+	t.Run("should divide floats", func(t *testing.T) {
+		expr := nodes.Div(nodes.Literal(7.0), nodes.Literal(2.0))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 3.5, got)
+	})
+}
+
+func Test_Div_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Div(nodes.Literal(2), nodes.Literal(1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -52,7 +52,7 @@ func (node *EqualNode) Eval(scope adapters.Scope) adapters.Value {
 		return adapters.NewNodeError(node, err)
 	}
 
-	value, ok := cmpAny(firstValue, secondValue)
+	equal, ok := equalAny(firstValue, secondValue)
 	if !ok {
 		return adapters.NewNodeError(node, adapters.IncompatiblePairError{
 			First:  firstValue,
@@ -60,7 +60,7 @@ func (node *EqualNode) Eval(scope adapters.Scope) adapters.Value {
 		})
 	}
 
-	return Literal(value == 0)
+	return Literal(equal)
 }
 
 func (node *EqualNode) Register(codex adapters.Codex) error {
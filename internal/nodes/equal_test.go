@@ -5,6 +5,7 @@ import (
 
 	"github.com/sonalys/gon"
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
 	"github.com/sonalys/gon/encoding"
 	"github.com/sonalys/gon/internal/nodes"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +37,27 @@ func Test_Equal(t *testing.T) {
 		require.True(t, ok)
 	})
 
+	t.Run("should compare decimals by value", func(t *testing.T) {
+		scope := gon.NewScope()
+		node := nodes.Equal(nodes.Sum(nodes.Literal(decimal.MustParse("0.1")), nodes.Literal(decimal.MustParse("0.2"))), nodes.Literal(decimal.MustParse("0.30")))
+
+		got, err := scope.Compute(node)
+		require.NoError(t, err)
+		require.True(t, got.(bool))
+	})
+
+	t.Run("should compare null values", func(t *testing.T) {
+		scope := gon.NewScope()
+
+		got, err := scope.Compute(nodes.Equal(nodes.Literal(nil), nodes.Literal((*int)(nil))))
+		require.NoError(t, err)
+		require.True(t, got.(bool))
+
+		got, err = scope.Compute(nodes.Equal(nodes.Literal(1), nodes.Literal(nil)))
+		require.NoError(t, err)
+		require.False(t, got.(bool))
+	})
+
 	t.Run("should return true for equality", func(t *testing.T) {
 		scope := gon.NewScope()
 		node := nodes.Equal(nodes.Literal(1), nodes.Literal(1))
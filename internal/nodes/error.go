@@ -0,0 +1,69 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type ErrorNode struct {
+	message adapters.Node
+}
+
+// Error defines a node that always fails with a CustomError, for rules that deliberately fail.
+// The message should evaluate to a string.
+// Example: if(lt(order.total, 0), error("negative total"), order.total).
+func Error(message adapters.Node) adapters.Node {
+	if message == nil {
+		return adapters.NodeError{
+			NodeScalar: "error",
+			Cause:      fmt.Errorf("message cannot be unset"),
+		}
+	}
+
+	return &ErrorNode{
+		message: message,
+	}
+}
+
+func (node *ErrorNode) Scalar() string {
+	return "error"
+}
+
+func (node *ErrorNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "message", Node: node.message},
+	}
+}
+
+func (node *ErrorNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *ErrorNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.message)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	message, ok := value.(string)
+	if !ok {
+		return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "string", Got: value})
+	}
+
+	return adapters.NewNodeError(node, adapters.CustomError{Message: message})
+}
+
+func (node *ErrorNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "message")
+		if err != nil {
+			return nil, err
+		}
+
+		return Error(orderedArgs["message"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &ErrorNode{}
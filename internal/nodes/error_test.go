@@ -0,0 +1,69 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Error(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should error on unset message", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Error(nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should fail with the custom message", func(t *testing.T) {
+		_, err := scope.Compute(nodes.If(nodes.Literal(true), nodes.Error(nodes.Literal("negative total"))))
+
+		var target adapters.CustomError
+		require.ErrorAs(t, err, &target)
+		require.Equal(t, "negative total", target.Message)
+		require.Equal(t, "if.then.error: negative total", err.Error())
+	})
+
+	t.Run("should error if message is not a string", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Error(nodes.Literal(1)))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+
+	t.Run("should propagate message error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Error(nodes.Literal(assert.AnError)))
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_Error_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Error(nodes.Literal("failed"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
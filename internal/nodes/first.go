@@ -0,0 +1,105 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+type FirstNode struct {
+	collection adapters.Node
+	last       bool
+}
+
+// First defines a node that evaluates to the first element of a collection, or null when it is empty.
+// Example: first(sort(orders, it, it.createdAt)).
+func First(collection adapters.Node) adapters.Node {
+	if collection == nil {
+		return adapters.NodeError{
+			NodeScalar: "first",
+			Cause:      fmt.Errorf("collection cannot be unset"),
+		}
+	}
+
+	return &FirstNode{
+		collection: collection,
+	}
+}
+
+// Last defines a node that evaluates to the last element of a collection, or null when it is empty.
+// Example: last(sort(orders, it, it.createdAt)).
+func Last(collection adapters.Node) adapters.Node {
+	if collection == nil {
+		return adapters.NodeError{
+			NodeScalar: "last",
+			Cause:      fmt.Errorf("collection cannot be unset"),
+		}
+	}
+
+	return &FirstNode{
+		collection: collection,
+		last:       true,
+	}
+}
+
+func (node *FirstNode) Scalar() string {
+	if node.last {
+		return "last"
+	}
+
+	return "first"
+}
+
+func (node *FirstNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "collection", Node: node.collection},
+	}
+}
+
+func (node *FirstNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *FirstNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	switch {
+	case len(elements) == 0:
+		return Literal(nil)
+	case node.last:
+		return Literal(elements[len(elements)-1])
+	default:
+		return Literal(elements[0])
+	}
+}
+
+func (node *FirstNode) Register(codex adapters.Codex) error {
+	err := codex.Register("first", func(args []adapters.KeyNode) (adapters.Node, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+		return First(args[0].Node), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = codex.Register("last", func(args []adapters.KeyNode) (adapters.Node, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+		return Last(args[0].Node), nil
+	})
+
+	return err
+}
+
+var _ adapters.SerializableNode = &FirstNode{}
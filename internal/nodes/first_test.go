@@ -0,0 +1,76 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_First(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset collection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.First(nil))
+		require.Error(t, err)
+
+		_, err = scope.Compute(nodes.Last(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.First(nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should return the first and last elements", func(t *testing.T) {
+		collection := nodes.Sort(nodes.Literal([]int{2, 3, 1}), "", nil)
+
+		got, err := scope.Compute(nodes.First(collection))
+		require.NoError(t, err)
+		require.Equal(t, 1, got)
+
+		got, err = scope.Compute(nodes.Last(collection))
+		require.NoError(t, err)
+		require.Equal(t, 3, got)
+	})
+
+	t.Run("should return null for empty collections", func(t *testing.T) {
+		got, err := scope.Compute(nodes.IsNull(nodes.First(nodes.Literal([]int{}))))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+}
+
+func Test_First_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Last(nodes.Reference("orders"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
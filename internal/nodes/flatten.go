@@ -0,0 +1,84 @@
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+type FlattenNode struct {
+	collection adapters.Node
+}
+
+// Flatten defines a node that flattens the nested slices and arrays of a collection by one level, into a new slice.
+// Other elements, like strings and maps, are kept as they are.
+// Example: flatten(orders.items).
+func Flatten(collection adapters.Node) adapters.Node {
+	if collection == nil {
+		return adapters.NodeError{
+			NodeScalar: "flatten",
+			Cause:      fmt.Errorf("collection cannot be unset"),
+		}
+	}
+
+	return &FlattenNode{
+		collection: collection,
+	}
+}
+
+func (node *FlattenNode) Scalar() string {
+	return "flatten"
+}
+
+func (node *FlattenNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "collection", Node: node.collection},
+	}
+}
+
+func (node *FlattenNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *FlattenNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	flattened := make([]any, 0, len(elements))
+
+	for _, element := range elements {
+		switch reflect.ValueOf(element).Kind() {
+		case reflect.Slice, reflect.Array:
+			nested, err := elementsOf(element)
+			if err != nil {
+				return adapters.NewNodeError(node, err)
+			}
+
+			flattened = append(flattened, nested...)
+		default:
+			flattened = append(flattened, element)
+		}
+	}
+
+	return Literal(flattened)
+}
+
+func (node *FlattenNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+
+		return Flatten(args[0].Node), nil
+	})
+}
+
+var _ adapters.SerializableNode = &FlattenNode{}
@@ -0,0 +1,68 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Flatten(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset collection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Flatten(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Flatten(nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should flatten one level", func(t *testing.T) {
+		collection := nodes.Literal([]any{[]int{1, 2}, 3, [1]string{"a"}, []any{[]int{4}}, "bc"})
+
+		got, err := scope.Compute(nodes.Flatten(collection))
+		require.NoError(t, err)
+		require.Equal(t, []any{1, 2, 3, "a", []int{4}, "bc"}, got)
+	})
+
+	t.Run("should error on values that aren't collections", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Flatten(nodes.Literal(1)))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Flatten_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Flatten(nodes.Reference("orders"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
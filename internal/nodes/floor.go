@@ -0,0 +1,70 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type FloorNode struct {
+	value adapters.Node
+}
+
+// Floor defines a node that rounds a number towards negative infinity, keeping its type.
+// Floats are rounded by their shortest decimal representation, like round, and integers are kept as they are.
+// Example: floor(div(order.total, 10.0)).
+func Floor(value adapters.Node) adapters.Node {
+	if value == nil {
+		return adapters.NodeError{
+			NodeScalar: "floor",
+			Cause:      fmt.Errorf("value cannot be unset"),
+		}
+	}
+
+	return &FloorNode{
+		value: value,
+	}
+}
+
+func (node *FloorNode) Scalar() string {
+	return "floor"
+}
+
+func (node *FloorNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+	}
+}
+
+func (node *FloorNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *FloorNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := roundAny(value, 0, decimal.Floor)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *FloorNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "value")
+		if err != nil {
+			return nil, err
+		}
+
+		return Floor(orderedArgs["value"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &FloorNode{}
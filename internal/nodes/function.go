@@ -0,0 +1,151 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type FunctionNode struct {
+	name     string
+	argNodes []adapters.Node
+}
+
+// Function defines a call to a function registered with the scope, written like a built-in node.
+// It evaluates all args before providing them to the function, with the same conversions as Call.
+// Unlike Call, argument errors are returned without calling the function.
+// Returns a NodeError if the scope doesn't implement adapters.FunctionReader or doesn't register the function.
+// Example: discount(order.total, 0.1).
+func Function(name string, argNodes ...adapters.Node) adapters.Node {
+	return &FunctionNode{
+		name:     name,
+		argNodes: argNodes,
+	}
+}
+
+func (node *FunctionNode) Scalar() string {
+	return node.name
+}
+
+func (node *FunctionNode) Shape() []adapters.KeyNode {
+	kv := make([]adapters.KeyNode, 0, len(node.argNodes))
+
+	for i := range node.argNodes {
+		kv = append(kv, adapters.KeyNode{Node: node.argNodes[i]})
+	}
+
+	return kv
+}
+
+func (node *FunctionNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *FunctionNode) Eval(scope adapters.Scope) adapters.Value {
+	values := make([]adapters.Value, 0, len(node.argNodes))
+
+	for i := range node.argNodes {
+		value := node.argNodes[i].Eval(scope)
+		if err, ok := value.Value().(error); ok {
+			return adapters.NewNodeError(node, err)
+		}
+
+		values = append(values, value)
+	}
+
+	reader, ok := scope.(adapters.FunctionReader)
+	if !ok {
+		return adapters.NewNodeError(node, adapters.DefinitionNotFoundError{
+			DefinitionKey: node.name,
+		})
+	}
+
+	callable, ok := reader.Function(node.name)
+	if !ok {
+		return adapters.NewNodeError(node, adapters.DefinitionNotFoundError{
+			DefinitionKey: node.name,
+		})
+	}
+
+	result := callable.Call(scope, "", values...)
+	if err, ok := result.Value().(error); ok {
+		return adapters.NewNodeError(node, adapters.CallError{
+			FuncName: node.name,
+			Cause:    err,
+		})
+	}
+
+	return result
+}
+
+// Register registers the function name without argument checks.
+// Use CheckFunctionArguments to validate arguments against the function signature when decoding.
+func (node *FunctionNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		_, rest, err := gonutils.SortArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return Function(node.name, rest...), nil
+	})
+}
+
+// CheckFunctionArguments validates the arguments for a function of the given type.
+// The argument count should match the parameters, ignoring a leading context parameter,
+// and literal arguments should be convertible to their parameter types.
+func CheckFunctionArguments(typeOfFunc reflect.Type, args []adapters.Node) error {
+	if typeOfFunc.Kind() != reflect.Func {
+		return fmt.Errorf("expected a function, got %s", typeOfFunc)
+	}
+
+	params := make([]reflect.Type, 0, typeOfFunc.NumIn())
+	for i := range typeOfFunc.NumIn() {
+		params = append(params, typeOfFunc.In(i))
+	}
+
+	if len(params) > 0 && receivesContext(context.Background(), params[0]) {
+		params = params[1:]
+	}
+
+	fixedParams := len(params)
+	if typeOfFunc.IsVariadic() {
+		fixedParams--
+	}
+
+	switch {
+	case typeOfFunc.IsVariadic() && len(args) < fixedParams:
+		return fmt.Errorf("expected at least %d args, got %d", fixedParams, len(args))
+	case !typeOfFunc.IsVariadic() && len(args) != fixedParams:
+		return fmt.Errorf("expected %d args, got %d", fixedParams, len(args))
+	}
+
+	for i := range args {
+		// Other arguments are only known when evaluated.
+		literal, ok := args[i].(*LiteralNode)
+		if !ok {
+			continue
+		}
+
+		var target reflect.Type
+		if i < fixedParams {
+			target = params[i]
+		} else {
+			target = params[fixedParams].Elem()
+		}
+
+		if _, ok := ConvertValue(literal.Value(), target); !ok {
+			return fmt.Errorf("argument %d: %w", i, adapters.TypeConversionError{
+				Value:  literal.Value(),
+				Target: target,
+			})
+		}
+	}
+
+	return nil
+}
+
+var _ adapters.SerializableNode = &FunctionNode{}
@@ -0,0 +1,99 @@
+package nodes_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Function(t *testing.T) {
+	scope, err := gon.NewScope().WithFunctions(gon.Functions{
+		"double": func(ctx context.Context, value int) int {
+			return value * 2
+		},
+		"fail": func() error {
+			return assert.AnError
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("should call the scope function", func(t *testing.T) {
+		value, err := scope.Compute(nodes.Function("double", gon.Int(2)))
+		require.NoError(t, err)
+		require.Equal(t, 4, value)
+	})
+
+	t.Run("should wrap function errors", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Function("fail"))
+		require.ErrorAs(t, err, &adapters.CallError{})
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should propagate argument errors", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Function("double", nodes.Reference("missing")))
+		require.ErrorAs(t, err, &adapters.DefinitionNotFoundError{})
+		require.NotErrorAs(t, err, &adapters.CallError{})
+	})
+
+	t.Run("should error if function is not found", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Function("missing"))
+		require.ErrorAs(t, err, &adapters.DefinitionNotFoundError{})
+	})
+}
+
+func Test_CheckFunctionArguments(t *testing.T) {
+	t.Run("should ignore context parameter", func(t *testing.T) {
+		typeOf := reflect.TypeOf(func(ctx context.Context, value int) int { return value })
+
+		require.NoError(t, nodes.CheckFunctionArguments(typeOf, []adapters.Node{gon.Int(1)}))
+		require.Error(t, nodes.CheckFunctionArguments(typeOf, nil))
+	})
+
+	t.Run("should check variadic arguments", func(t *testing.T) {
+		typeOf := reflect.TypeOf(func(separator string, values ...int) string { return separator })
+
+		require.NoError(t, nodes.CheckFunctionArguments(typeOf, []adapters.Node{nodes.Literal(","), gon.Int(1), nodes.Reference("value")}))
+		require.Error(t, nodes.CheckFunctionArguments(typeOf, nil))
+		require.Error(t, nodes.CheckFunctionArguments(typeOf, []adapters.Node{nodes.Literal(","), nodes.Literal("a")}))
+	})
+
+	t.Run("should error if not a function", func(t *testing.T) {
+		require.Error(t, nodes.CheckFunctionArguments(reflect.TypeOf(1), nil))
+	})
+}
+
+func Test_Function_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Function("funcName", nodes.Literal(1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
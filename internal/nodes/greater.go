@@ -82,6 +82,10 @@ func (node *GreaterNode) Eval(scope adapters.Scope) adapters.Value {
 		return adapters.NewNodeError(node, err)
 	}
 
+	if hasNull(firstValue, secondValue) {
+		return adapters.NewNodeError(node, adapters.ErrNullOperand)
+	}
+
 	comparison, ok := cmpAny(firstValue, secondValue)
 	if !ok {
 		return adapters.NewNodeError(node, adapters.IncompatiblePairError{
@@ -114,7 +118,7 @@ func (node *GreaterNode) Register(codex adapters.Codex) error {
 		if err != nil {
 			return nil, err
 		}
-		return Greater(orderedArgs["first"], orderedArgs["second"]), nil
+		return GreaterOrEqual(orderedArgs["first"], orderedArgs["second"]), nil
 	})
 
 	return err
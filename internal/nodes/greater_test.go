@@ -5,6 +5,7 @@ import (
 
 	"github.com/sonalys/gon"
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
 	"github.com/sonalys/gon/encoding"
 	"github.com/sonalys/gon/internal/nodes"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +37,23 @@ func Test_Greater(t *testing.T) {
 		require.True(t, ok)
 	})
 
+	t.Run("should compare decimals with integers", func(t *testing.T) {
+		scope := gon.NewScope()
+		node := nodes.Greater(nodes.Literal(decimal.MustParse("10.50")), nodes.Literal(int64(10)))
+
+		got, err := scope.Compute(node)
+		require.NoError(t, err)
+		require.True(t, got.(bool))
+	})
+
+	t.Run("cannot compare null", func(t *testing.T) {
+		scope := gon.NewScope()
+		node := nodes.Greater(nodes.Literal(1), nodes.Literal(nil))
+
+		_, err := scope.Compute(node)
+		require.ErrorIs(t, err, adapters.ErrNullOperand)
+	})
+
 	t.Run("should return true for greater", func(t *testing.T) {
 		scope := gon.NewScope()
 		node := nodes.Greater(nodes.Literal(2), nodes.Literal(1))
@@ -144,4 +162,31 @@ func Test_Greater_Encoding(t *testing.T) {
 			require.Equal(t, node, got)
 		})
 	})
+
+	t.Run("should decode inclusive with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.GreaterOrEqual(nodes.Literal(true), nodes.Literal(1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
 }
@@ -0,0 +1,104 @@
+package nodes
+
+import (
+	"reflect"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+type GroupByNode struct {
+	collection adapters.Node
+	variable   string
+	projection adapters.Node
+}
+
+// GroupBy defines a node that groups the elements of a collection by their projection, into a map of slices.
+// Keys are compared like equal, and each group is keyed by its first key, keeping the order of the elements.
+// Groups are collections themselves, so they can be aggregated or referenced by key.
+// Example: groupBy(cart.items, it, it.category).
+func GroupBy(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	if projection == nil {
+		return adapters.NodeError{
+			NodeScalar: "groupBy",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	if err := validateProjection(collection, variable, projection); err != nil {
+		return adapters.NodeError{
+			NodeScalar: "groupBy",
+			Cause:      err,
+		}
+	}
+
+	return &GroupByNode{
+		collection: collection,
+		variable:   variable,
+		projection: projection,
+	}
+}
+
+func (node *GroupByNode) Scalar() string {
+	return "groupBy"
+}
+
+func (node *GroupByNode) Shape() []adapters.KeyNode {
+	return projectionShape(node.collection, node.variable, node.projection)
+}
+
+func (node *GroupByNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *GroupByNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	keys, err := projectElements(scope, elements, node.variable, node.projection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	groups := make(map[any][]any)
+	groupKeys := make([]any, 0, len(keys))
+
+	for i := range elements {
+		index, err := indexOf(keys[i], groupKeys)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		if index < 0 {
+			if !isNull(keys[i]) && !reflect.ValueOf(keys[i]).Comparable() {
+				return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "comparable key", Got: keys[i]})
+			}
+
+			groupKeys = append(groupKeys, keys[i])
+			index = len(groupKeys) - 1
+		}
+
+		groups[groupKeys[index]] = append(groups[groupKeys[index]], elements[i])
+	}
+
+	return Literal(groups)
+}
+
+func (node *GroupByNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		collection, variable, projection, err := sortProjectionArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return GroupBy(collection, variable, projection), nil
+	})
+}
+
+var _ adapters.SerializableNode = &GroupByNode{}
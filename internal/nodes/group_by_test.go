@@ -0,0 +1,85 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GroupBy(t *testing.T) {
+	scope := gon.NewScope()
+
+	items := nodes.Literal([]cartItem{
+		{Name: "book", Price: 10},
+		{Name: "pen", Price: 2.5},
+		{Name: "lamp", Price: 10},
+	})
+
+	t.Run("should have a projection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.GroupBy(items, "", nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.GroupBy(nodes.Literal(assert.AnError), "it", nodes.Reference("it")))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should group by the projection", func(t *testing.T) {
+		got, err := scope.Compute(nodes.GroupBy(items, "it", nodes.Reference("it.price")))
+		require.NoError(t, err)
+		require.Equal(t, map[any][]any{
+			10.0: {cartItem{Name: "book", Price: 10}, cartItem{Name: "lamp", Price: 10}},
+			2.5:  {cartItem{Name: "pen", Price: 2.5}},
+		}, got)
+	})
+
+	t.Run("should aggregate the groups", func(t *testing.T) {
+		groups := nodes.GroupBy(nodes.Literal([]string{"a", "bb", "c"}), "it", nodes.Reference("it"))
+
+		got, err := scope.Compute(nodes.CountOf(groups, "", nil))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), got)
+	})
+
+	t.Run("should error on keys that can't be compared", func(t *testing.T) {
+		nested := nodes.Literal([]any{[]int{1}, []int{1}})
+
+		_, err := scope.Compute(nodes.GroupBy(nested, "it", nodes.Reference("it")))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_GroupBy_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.GroupBy(nodes.Reference("cart.items"), "it", nodes.Reference("it.category"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -64,7 +64,7 @@ func (node *IfNode) Eval(scope adapters.Scope) adapters.Value {
 
 	fulfilled, ok := value.(bool)
 	if !ok {
-		return adapters.NewNodeError(node, fmt.Errorf("expected bool got %T", value))
+		return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "bool", Got: value})
 	}
 
 	if fulfilled {
@@ -0,0 +1,80 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type ImpliesNode struct {
+	condition   adapters.Node
+	consequence adapters.Node
+}
+
+// Implies defines an implication node, which is true when the condition is false, without evaluating the consequence.
+// Otherwise, it returns the value of the consequence.
+// Like or, it returns the value of the first error or non-boolean condition.
+// Example: implies(order.international, exists("order.customs")).
+func Implies(condition, consequence adapters.Node) adapters.Node {
+	if condition == nil || consequence == nil {
+		return adapters.NodeError{
+			NodeScalar: "implies",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &ImpliesNode{
+		condition:   condition,
+		consequence: consequence,
+	}
+}
+
+func (node *ImpliesNode) Scalar() string {
+	return "implies"
+}
+
+func (node *ImpliesNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "condition", Node: node.condition},
+		{Key: "consequence", Node: node.consequence},
+	}
+}
+
+func (node *ImpliesNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *ImpliesNode) Eval(scope adapters.Scope) adapters.Value {
+	condition, err := scope.Compute(node.condition)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	switch condition := condition.(type) {
+	case bool:
+		if !condition {
+			return Literal(true)
+		}
+	default:
+		return Literal(condition)
+	}
+
+	consequence, err := scope.Compute(node.consequence)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(consequence)
+}
+
+func (node *ImpliesNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "condition", "consequence")
+		if err != nil {
+			return nil, err
+		}
+
+		return Implies(orderedArgs["condition"], orderedArgs["consequence"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &ImpliesNode{}
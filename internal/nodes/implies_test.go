@@ -0,0 +1,77 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Implies(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Implies(nodes.Literal(true), nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Implies(nodes.Literal(true), nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should not evaluate the consequence of a false condition", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Implies(nodes.Literal(false), nodes.Literal(assert.AnError)))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+
+	t.Run("should return the consequence of a true condition", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Implies(nodes.Literal(true), nodes.Literal(false)))
+		require.NoError(t, err)
+		require.Equal(t, false, got)
+
+		got, err = scope.Compute(nodes.Implies(nodes.Literal(true), nodes.Literal(true)))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+
+	t.Run("should return non-boolean conditions like or", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Implies(nodes.Literal("value"), nodes.Literal(false)))
+		require.NoError(t, err)
+		require.Equal(t, "value", got)
+	})
+}
+
+func Test_Implies_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Implies(nodes.Reference("order.international"), nodes.Reference("order.declared"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
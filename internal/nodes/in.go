@@ -0,0 +1,80 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type InNode struct {
+	value      adapters.Node
+	collection adapters.Node
+}
+
+// In defines a node that evaluates to true when the collection has an element equal to the value.
+// Elements are compared like equal, so null is only in collections with null elements.
+// Example: in(order.status, allowed_statuses).
+func In(value, collection adapters.Node) adapters.Node {
+	if value == nil || collection == nil {
+		return adapters.NodeError{
+			NodeScalar: "in",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &InNode{
+		value:      value,
+		collection: collection,
+	}
+}
+
+func (node *InNode) Scalar() string {
+	return "in"
+}
+
+func (node *InNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+		{Key: "collection", Node: node.collection},
+	}
+}
+
+func (node *InNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *InNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	collection, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	index, err := indexOf(value, elements)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(index >= 0)
+}
+
+func (node *InNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "value", "collection")
+		if err != nil {
+			return nil, err
+		}
+
+		return In(orderedArgs["value"], orderedArgs["collection"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &InNode{}
@@ -0,0 +1,85 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_In(t *testing.T) {
+	scope := gon.NewScope()
+
+	collection := nodes.Literal([]any{"open", "paid", nil})
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.In(nil, collection))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.In(nodes.Literal(assert.AnError), collection))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should find equal elements", func(t *testing.T) {
+		testCases := []struct {
+			value    any
+			expected bool
+		}{
+			{"paid", true},
+			{"closed", false},
+			{nil, true},
+		}
+
+		for _, tc := range testCases {
+			got, err := scope.Compute(nodes.In(nodes.Literal(tc.value), collection))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got, tc.value)
+		}
+	})
+
+	t.Run("should search transformed collections", func(t *testing.T) {
+		got, err := scope.Compute(nodes.In(nodes.Literal(2), nodes.Slice(nodes.Literal([]int{1, 2, 3}), nodes.Literal(1), nil)))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+
+	t.Run("should error on mixed types", func(t *testing.T) {
+		_, err := scope.Compute(nodes.In(nodes.Literal(1), collection))
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+	})
+}
+
+func Test_In_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.In(nodes.Reference("order.status"), nodes.Reference("statuses"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
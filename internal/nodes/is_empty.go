@@ -37,6 +37,9 @@ func (node *IsEmptyNode) Eval(scope adapters.Scope) adapters.Value {
 	}
 
 	switch valueOf.Kind() {
+	case reflect.Invalid:
+		// Null values, including nil pointers, are empty.
+		return Literal(true)
 	case reflect.Chan, reflect.Map, reflect.Slice, reflect.Array, reflect.String:
 		return Literal(valueOf.Len() == 0)
 	default:
@@ -106,6 +106,14 @@ func Test_IsEmpty(t *testing.T) {
 		assert.True(t, got.(bool))
 	})
 
+	t.Run("should be true for null", func(t *testing.T) {
+		for _, value := range []any{nil, (*[]int)(nil)} {
+			got, err := scope.Compute(gon.IsEmpty(gon.Literal(value)))
+			require.NoError(t, err)
+			assert.True(t, got.(bool), "%#v", value)
+		}
+	})
+
 	t.Run("should work for pointer", func(t *testing.T) {
 		value := &[]int{1}
 
@@ -0,0 +1,59 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type IsErrorNode struct {
+	expression adapters.Node
+}
+
+// IsError defines a node that evaluates to true when the expression fails, and false otherwise.
+func IsError(expression adapters.Node) adapters.Node {
+	if expression == nil {
+		return adapters.NodeError{
+			NodeScalar: "isError",
+			Cause:      fmt.Errorf("expression cannot be unset"),
+		}
+	}
+
+	return &IsErrorNode{
+		expression: expression,
+	}
+}
+
+func (node *IsErrorNode) Scalar() string {
+	return "isError"
+}
+
+func (node *IsErrorNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "expression", Node: node.expression},
+	}
+}
+
+func (node *IsErrorNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *IsErrorNode) Eval(scope adapters.Scope) adapters.Value {
+	_, err := scope.Compute(node.expression)
+
+	return Literal(err != nil)
+}
+
+func (node *IsErrorNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "expression")
+		if err != nil {
+			return nil, err
+		}
+
+		return IsError(orderedArgs["expression"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &IsErrorNode{}
@@ -0,0 +1,62 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsError(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should error on unset node", func(t *testing.T) {
+		_, err := scope.Compute(nodes.IsError(nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should return true for errors", func(t *testing.T) {
+		value, err := scope.Compute(nodes.IsError(nodes.Reference("missing")))
+		require.NoError(t, err)
+		require.True(t, value.(bool))
+	})
+
+	t.Run("should return false for values", func(t *testing.T) {
+		value, err := scope.Compute(nodes.IsError(nodes.Literal(1)))
+		require.NoError(t, err)
+		require.False(t, value.(bool))
+	})
+}
+
+func Test_IsError_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.IsError(nodes.Reference("value"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
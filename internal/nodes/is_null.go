@@ -0,0 +1,63 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+type IsNullNode struct {
+	node adapters.Node
+}
+
+// IsNull defines a node that evaluates to true when the value is null, like a nil pointer, map, slice or interface.
+// Unlike isEmpty, empty strings and collections are not null.
+func IsNull(node adapters.Node) adapters.Node {
+	if node == nil {
+		return adapters.NodeError{
+			NodeScalar: "isNull",
+			Cause:      fmt.Errorf("node cannot be unset"),
+		}
+	}
+
+	return &IsNullNode{
+		node: node,
+	}
+}
+
+func (node *IsNullNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.node)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(isNull(value))
+}
+
+func (node *IsNullNode) Scalar() string {
+	return "isNull"
+}
+
+func (node *IsNullNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "", Node: node.node},
+	}
+}
+
+func (node *IsNullNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(kn []adapters.KeyNode) (adapters.Node, error) {
+		if len(kn) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(kn))
+		}
+
+		return IsNull(kn[0].Node), nil
+	})
+}
+
+func (node *IsNullNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+var (
+	_ adapters.SerializableNode = &IsNullNode{}
+)
@@ -0,0 +1,91 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsNull(t *testing.T) {
+	type address struct {
+		City string `gon:"city"`
+	}
+
+	type person struct {
+		Address *address `gon:"address"`
+	}
+
+	scope, err := gon.NewScope().WithValues(gon.Values{
+		"person": gon.Literal(person{}),
+	})
+	require.NoError(t, err)
+
+	t.Run("should error on unset node", func(t *testing.T) {
+		node := gon.IsNull(nil)
+
+		_, err := scope.Compute(node)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		node := gon.IsNull(gon.Literal(assert.AnError))
+
+		_, err := scope.Compute(node)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should be true for null values", func(t *testing.T) {
+		for _, value := range []any{nil, (*int)(nil), map[string]int(nil), []int(nil)} {
+			got, err := scope.Compute(gon.IsNull(gon.Literal(value)))
+			require.NoError(t, err)
+			assert.True(t, got.(bool), "%#v", value)
+		}
+	})
+
+	t.Run("should be false for empty values", func(t *testing.T) {
+		for _, value := range []any{"", 0, false, []int{}, map[string]int{}} {
+			got, err := scope.Compute(gon.IsNull(gon.Literal(value)))
+			require.NoError(t, err)
+			assert.False(t, got.(bool), "%#v", value)
+		}
+	})
+
+	t.Run("should be true for nil pointer fields", func(t *testing.T) {
+		got, err := scope.Compute(gon.IsNull(gon.Reference("person.address")))
+		require.NoError(t, err)
+		assert.True(t, got.(bool))
+	})
+}
+
+func Test_IsNull_Encoding(t *testing.T) {
+	t.Run("should decode with child", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := gon.IsNull(gon.Literal(nil))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
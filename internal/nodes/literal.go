@@ -3,10 +3,11 @@ package nodes
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/sonalys/gon/adapters"
 )
@@ -18,19 +19,18 @@ type (
 	}
 )
 
-var typeOfContext = reflect.TypeOf(context.Background())
-
 // Literal represents a value/node.
 // Use Literal with functions to define callable definitions.
 // Use Literal with structs or maps to define definitions with children attributes.
-// time.Time is serialized as time(RFC3339) by default.
+// Slice and array elements are referenced by their index. Example: items.0.name.
+// Types with a LiteralCodec, like time.Time and time.Duration, are serialized as named expressions. Example: time(RFC3339Nano).
 func Literal(value any) *LiteralNode {
 	valueOf := reflect.ValueOf(value)
 
 	var isLazy bool
 	if valueOf.IsValid() {
 		typeOf := valueOf.Type()
-		isLazy = typeOf.Kind() == reflect.Func && (typeOf.NumIn() == 0 || typeOfContext.AssignableTo(typeOf.In(0)))
+		isLazy = typeOf.Kind() == reflect.Func && (typeOf.NumIn() == 0 || receivesContext(context.Background(), typeOf.In(0)))
 	}
 
 	return &LiteralNode{
@@ -40,44 +40,31 @@ func Literal(value any) *LiteralNode {
 }
 
 func (node *LiteralNode) Scalar() string {
-	if !node.value.IsValid() || !node.value.CanInterface() {
-		return "literal"
+	if codec, ok := literalCodecOf(node.value); ok {
+		return codec.Name
 	}
 
-	switch node.value.Interface().(type) {
-	case time.Time:
-		return "time"
-	default:
-		return "literal"
-	}
+	return "literal"
 }
 
 func (node *LiteralNode) Shape() []adapters.KeyNode {
-	if !node.value.IsValid() || !node.value.CanInterface() {
+	if codec, ok := literalCodecOf(node.value); ok {
 		return []adapters.KeyNode{
-			{Node: node},
+			{Key: "", Node: Literal(codec.Encode(node.value.Interface()))},
 		}
 	}
 
-	switch v := node.value.Interface().(type) {
-	case time.Time:
-		return []adapters.KeyNode{
-			{Key: "", Node: Literal(v.Format(time.RFC3339))},
-		}
-	default:
-		return []adapters.KeyNode{
-			{Node: node},
-		}
+	return []adapters.KeyNode{
+		{Node: node},
 	}
 }
 
 func (node *LiteralNode) Type() adapters.NodeType {
-	switch node.value.Interface().(type) {
-	case time.Time:
+	if _, ok := literalCodecOf(node.value); ok {
 		return adapters.NodeTypeExpression
-	default:
-		return adapters.NodeTypeLiteral
 	}
+
+	return adapters.NodeTypeLiteral
 }
 
 func (node *LiteralNode) Value() any {
@@ -103,8 +90,8 @@ func (node *LiteralNode) Call(ctx context.Context, key string, args ...adapters.
 
 	curValue := node.value
 	for i, partKey := range parts {
-		// Pointer resolver.
-		for ; curValue.Kind() == reflect.Pointer; curValue = curValue.Elem() {
+		// Pointer and interface resolver.
+		for ; curValue.Kind() == reflect.Pointer || curValue.Kind() == reflect.Interface; curValue = curValue.Elem() {
 		}
 
 		switch curValue.Kind() {
@@ -125,67 +112,179 @@ func (node *LiteralNode) Call(ctx context.Context, key string, args ...adapters.
 		}
 	}
 
-	typeOfFunc := curValue.Type()
-
 	if curValue.Kind() != reflect.Func {
 		return adapters.NewNodeError(node, adapters.DefinitionNotCallableError{
 			DefinitionKey: key,
 		})
 	}
 
-	expArgs := typeOfFunc.NumIn()
-	gotArgs := len(args)
-	var gotContext bool
-	if expArgs > 0 {
-		if reflect.TypeOf(ctx).AssignableTo(typeOfFunc.In(0)) {
-			gotArgs += 1
-			gotContext = true
-		}
+	argsValue, err := callArguments(ctx, curValue.Type(), args)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
 	}
 
-	if gotArgs != expArgs {
-		return adapters.NewNodeError(node, fmt.Errorf("expected %d args, got %d", expArgs, gotArgs))
+	return callResult(curValue.Call(argsValue))
+}
+
+var errorType = reflect.TypeFor[error]()
+
+// receivesContext reports if the context can be given to the parameter.
+// Empty interfaces don't receive the context, so functions like func(...any) only receive the arguments.
+func receivesContext(ctx context.Context, param reflect.Type) bool {
+	return param.NumMethod() > 0 && reflect.TypeOf(ctx).AssignableTo(param)
+}
+
+// callArguments converts the arguments to the parameter types of the function.
+// The context is given to functions receiving it as first parameter,
+// variadic parameters receive the remaining arguments,
+// and keyword arguments fill the fields of a last struct parameter, by their gon tags.
+func callArguments(ctx context.Context, typeOfFunc reflect.Type, args []adapters.Value) ([]reflect.Value, error) {
+	params := make([]reflect.Type, 0, typeOfFunc.NumIn())
+	for i := range typeOfFunc.NumIn() {
+		params = append(params, typeOfFunc.In(i))
 	}
 
-	argsValue := make([]reflect.Value, 0, expArgs)
-	if gotContext {
+	argsValue := make([]reflect.Value, 0, len(params)+len(args))
+
+	if len(params) > 0 && receivesContext(ctx, params[0]) {
 		argsValue = append(argsValue, reflect.ValueOf(ctx))
+		params = params[1:]
+	}
+
+	var keywords adapters.KeywordArgs
+	if len(args) > 0 {
+		keywords, _ = args[len(args)-1].Value().(adapters.KeywordArgs)
+	}
+
+	var keywordsParam reflect.Type
+	if keywords != nil {
+		args = args[:len(args)-1]
+
+		if len(params) == 0 || typeOfFunc.IsVariadic() || !isStruct(params[len(params)-1]) {
+			return nil, fmt.Errorf("function does not receive keyword arguments")
+		}
+
+		keywordsParam = params[len(params)-1]
+		params = params[:len(params)-1]
+	}
+
+	fixedParams := len(params)
+	if typeOfFunc.IsVariadic() {
+		fixedParams--
+	}
+
+	switch {
+	case typeOfFunc.IsVariadic() && len(args) < fixedParams:
+		return nil, fmt.Errorf("expected at least %d args, got %d", fixedParams, len(args))
+	case !typeOfFunc.IsVariadic() && len(args) != fixedParams:
+		return nil, fmt.Errorf("expected %d args, got %d", fixedParams, len(args))
 	}
 
 	for i := range args {
-		valueOfArg := reflect.ValueOf(args[i].Value())
-		typeOfArg := valueOfArg.Type()
+		var target reflect.Type
+		if i < fixedParams {
+			target = params[i]
+		} else {
+			target = params[fixedParams].Elem()
+		}
 
-		targetParamIndex := i
-		if gotContext {
-			targetParamIndex += 1
+		converted, ok := ConvertValue(args[i].Value(), target)
+		if !ok {
+			return nil, fmt.Errorf("argument %d: %w", i, adapters.TypeConversionError{
+				Value:  args[i].Value(),
+				Target: target,
+			})
 		}
-		expectedTypeOfArg := typeOfFunc.In(targetParamIndex)
 
-		if !typeOfArg.AssignableTo(expectedTypeOfArg) {
-			return adapters.NewNodeError(node, fmt.Errorf("argument mismatch for function, arg %d expected %s, got %s", targetParamIndex, expectedTypeOfArg.String(), typeOfArg.String()))
+		argsValue = append(argsValue, converted)
+	}
+
+	if keywordsParam != nil {
+		keywordsValue, err := keywordStruct(keywordsParam, keywords)
+		if err != nil {
+			return nil, err
 		}
 
-		argsValue = append(argsValue, valueOfArg)
+		argsValue = append(argsValue, keywordsValue)
 	}
 
-	resp := curValue.Call(argsValue)
+	return argsValue, nil
+}
 
-	expResp := typeOfFunc.NumOut()
-	if expResp == 0 {
-		return Literal(nil)
+// keywordStruct returns a struct, or pointer to struct, with the fields tagged with the keywords set.
+func keywordStruct(target reflect.Type, keywords adapters.KeywordArgs) (reflect.Value, error) {
+	structType := target
+	if target.Kind() == reflect.Pointer {
+		structType = target.Elem()
+	}
+
+	result := reflect.New(structType)
+
+	for _, keyword := range slices.Sorted(maps.Keys(keywords)) {
+		field, ok := fieldByTag(structType, keyword)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("unknown keyword argument '%s'", keyword)
+		}
+
+		converted, ok := ConvertValue(keywords[keyword], field.Type)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("keyword argument '%s': %w", keyword, adapters.TypeConversionError{
+				Value:  keywords[keyword],
+				Target: field.Type,
+			})
+		}
+
+		result.Elem().FieldByIndex(field.Index).Set(converted)
+	}
+
+	if target.Kind() == reflect.Pointer {
+		return result, nil
+	}
+
+	return result.Elem(), nil
+}
+
+func fieldByTag(structType reflect.Type, tag string) (reflect.StructField, bool) {
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		if field.IsExported() && field.Tag.Get("gon") == tag {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func isStruct(typeOf reflect.Type) bool {
+	return typeOf.Kind() == reflect.Struct ||
+		typeOf.Kind() == reflect.Pointer && typeOf.Elem().Kind() == reflect.Struct
+}
+
+// callResult returns the results of a function call.
+// A trailing error result is returned as the value when it is not nil, and dropped otherwise.
+// Functions with multiple results return them as a []any.
+func callResult(results []reflect.Value) adapters.Value {
+	if last := len(results) - 1; last >= 0 && results[last].Type() == errorType {
+		if err, ok := results[last].Interface().(error); ok {
+			return Literal(err)
+		}
+
+		results = results[:last]
 	}
 
-	if typeOfFunc.NumOut() == 1 {
-		return Literal(resp[0].Interface())
+	switch len(results) {
+	case 0:
+		return Literal(nil)
+	case 1:
+		return Literal(results[0].Interface())
 	}
 
-	respValue := make([]adapters.Value, 0, len(resp))
-	for i := range resp {
-		respValue = append(respValue, Literal(resp[i].Interface()))
+	values := make([]any, 0, len(results))
+	for i := range results {
+		values = append(values, results[i].Interface())
 	}
 
-	return Literal(respValue)
+	return Literal(values)
 }
 
 func (node *LiteralNode) Definition(key string) (adapters.Value, bool) {
@@ -198,8 +297,8 @@ func (node *LiteralNode) Definition(key string) (adapters.Value, bool) {
 	}
 
 	for i, partKey := range parts {
-		// Pointer reference resolver, necessary to resolve pointer fields.
-		for ; curValue.Kind() == reflect.Pointer; curValue = curValue.Elem() {
+		// Pointer and interface resolver, necessary to resolve pointer fields and values of untyped maps.
+		for ; curValue.Kind() == reflect.Pointer || curValue.Kind() == reflect.Interface; curValue = curValue.Elem() {
 		}
 
 		switch curValue.Kind() {
@@ -211,6 +310,16 @@ func (node *LiteralNode) Definition(key string) (adapters.Value, bool) {
 			})
 		case reflect.Map:
 			curValue = curValue.MapIndex(reflect.ValueOf(partKey))
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(partKey)
+			if err != nil || index < 0 || index >= curValue.Len() {
+				curValue = reflect.Value{}
+				break
+			}
+
+			curValue = curValue.Index(index)
+		default:
+			curValue = reflect.Value{}
 		}
 
 		if !curValue.IsValid() {
@@ -227,29 +336,13 @@ func (node *LiteralNode) Definition(key string) (adapters.Value, bool) {
 }
 
 func (node *LiteralNode) Register(codex adapters.Codex) error {
-	err := codex.Register("time", func(args []adapters.KeyNode) (adapters.Node, error) {
-		valuer, ok := args[0].Node.(adapters.Valued)
-		if !ok {
-			return nil, fmt.Errorf("invalid value received")
-		}
-
-		rawTime, ok := valuer.Value().(string)
-		if !ok {
-			return nil, fmt.Errorf("time should be parsed only from string")
+	for _, codec := range LiteralCodecs() {
+		if err := codex.Register(codec.Name, codec.Constructor()); err != nil {
+			return err
 		}
-
-		t, err := time.Parse(time.RFC3339, rawTime)
-		if err != nil {
-			return nil, fmt.Errorf("time is invalid: %w", err)
-		}
-
-		return Literal(t), nil
-	})
-	if err != nil {
-		return err
 	}
 
-	err = codex.Register("bool", func(args []adapters.KeyNode) (adapters.Node, error) {
+	err := codex.Register("bool", func(args []adapters.KeyNode) (adapters.Node, error) {
 		valuer, ok := args[0].Node.(adapters.Valued)
 		if !ok {
 			return nil, fmt.Errorf("invalid value received")
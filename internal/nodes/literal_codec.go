@@ -0,0 +1,163 @@
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+)
+
+// LiteralCodec encodes literals of a Go type as a named expression with a single string argument.
+// Example: time.Time is encoded as time("2024-01-01T00:00:00Z").
+type LiteralCodec struct {
+	// Name is the scalar of the expression, like time or uuid.
+	Name string
+	// Encode formats the value as the expression argument.
+	Encode func(value any) string
+	// Decode parses the expression argument into a value of the codec type.
+	Decode func(raw string) (any, error)
+}
+
+var literalCodecs = struct {
+	sync.RWMutex
+	byType map[reflect.Type]LiteralCodec
+}{
+	byType: make(map[reflect.Type]LiteralCodec),
+}
+
+func init() {
+	err := RegisterLiteralCodec(reflect.TypeFor[time.Time](), LiteralCodec{
+		Name: "time",
+		Encode: func(value any) string {
+			return value.(time.Time).Format(time.RFC3339Nano)
+		},
+		Decode: func(raw string) (any, error) {
+			return time.Parse(time.RFC3339Nano, raw)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = RegisterLiteralCodec(reflect.TypeFor[decimal.Decimal](), LiteralCodec{
+		Name: "decimal",
+		Encode: func(value any) string {
+			return value.(decimal.Decimal).String()
+		},
+		Decode: func(raw string) (any, error) {
+			return decimal.Parse(raw)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = RegisterLiteralCodec(reflect.TypeFor[time.Duration](), LiteralCodec{
+		Name: "duration",
+		Encode: func(value any) string {
+			return value.(time.Duration).String()
+		},
+		Decode: func(raw string) (any, error) {
+			return time.ParseDuration(raw)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RegisterLiteralCodec registers the codec for literals of the given type.
+// Each type and name can only be registered once.
+func RegisterLiteralCodec(typeOf reflect.Type, codec LiteralCodec) error {
+	if typeOf == nil || codec.Name == "" || codec.Encode == nil || codec.Decode == nil {
+		return fmt.Errorf("literal codec should have a type, a name, an encoder and a decoder")
+	}
+
+	literalCodecs.Lock()
+	defer literalCodecs.Unlock()
+
+	for registered, other := range literalCodecs.byType {
+		if registered == typeOf || other.Name == codec.Name {
+			return fmt.Errorf("literal codec '%s' for %s conflicts with '%s' for %s", codec.Name, typeOf, other.Name, registered)
+		}
+	}
+
+	literalCodecs.byType[typeOf] = codec
+
+	return nil
+}
+
+// UnregisterLiteralCodec removes the codec of the given type, so tests can undo their registrations.
+func UnregisterLiteralCodec(typeOf reflect.Type) {
+	literalCodecs.Lock()
+	defer literalCodecs.Unlock()
+
+	delete(literalCodecs.byType, typeOf)
+}
+
+// LiteralCodecs returns the registered codecs, sorted by name.
+func LiteralCodecs() []LiteralCodec {
+	literalCodecs.RLock()
+	defer literalCodecs.RUnlock()
+
+	codecs := make([]LiteralCodec, 0, len(literalCodecs.byType))
+	for _, codec := range literalCodecs.byType {
+		codecs = append(codecs, codec)
+	}
+
+	slices.SortFunc(codecs, func(a, b LiteralCodec) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return codecs
+}
+
+// HasLiteralCodec reports whether the value type has a registered codec.
+func HasLiteralCodec(value any) bool {
+	_, ok := literalCodecOf(reflect.ValueOf(value))
+	return ok
+}
+
+func literalCodecOf(value reflect.Value) (LiteralCodec, bool) {
+	if !value.IsValid() || !value.CanInterface() {
+		return LiteralCodec{}, false
+	}
+
+	literalCodecs.RLock()
+	defer literalCodecs.RUnlock()
+
+	codec, ok := literalCodecs.byType[value.Type()]
+
+	return codec, ok
+}
+
+// Constructor returns the codex constructor of the codec, building literals from the string argument.
+func (codec LiteralCodec) Constructor() func([]adapters.KeyNode) (adapters.Node, error) {
+	return func(args []adapters.KeyNode) (adapters.Node, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+
+		valuer, ok := args[0].Node.(adapters.Valued)
+		if !ok {
+			return nil, fmt.Errorf("invalid value received")
+		}
+
+		raw, ok := valuer.Value().(string)
+		if !ok {
+			return nil, fmt.Errorf("%s should be parsed only from string", codec.Name)
+		}
+
+		value, err := codec.Decode(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s is invalid: %w", codec.Name, err)
+		}
+
+		return Literal(value), nil
+	}
+}
@@ -0,0 +1,37 @@
+package nodes_test
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegisterLiteralCodec(t *testing.T) {
+	t.Run("should require all fields", func(t *testing.T) {
+		err := nodes.RegisterLiteralCodec(reflect.TypeFor[struct{}](), nodes.LiteralCodec{Name: "empty"})
+		require.Error(t, err)
+	})
+
+	t.Run("should not register a type twice", func(t *testing.T) {
+		err := nodes.RegisterLiteralCodec(reflect.TypeFor[time.Time](), nodes.LiteralCodec{
+			Name:   "date",
+			Encode: func(value any) string { return "" },
+			Decode: func(raw string) (any, error) { return nil, nil },
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("should list codecs by name", func(t *testing.T) {
+		codecs := nodes.LiteralCodecs()
+		require.True(t, slices.IsSortedFunc(codecs, func(a, b nodes.LiteralCodec) int {
+			return strings.Compare(a.Name, b.Name)
+		}))
+		require.True(t, nodes.HasLiteralCodec(time.Second))
+		require.False(t, nodes.HasLiteralCodec(int64(1)))
+	})
+}
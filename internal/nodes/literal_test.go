@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
 	"github.com/sonalys/gon/encoding"
 	"github.com/sonalys/gon/internal/nodes"
 	"github.com/stretchr/testify/assert"
@@ -187,6 +188,66 @@ func Test_Literal_Definition(t *testing.T) {
 
 		require.Equal(t, expected, gotValue.Value())
 	})
+
+	t.Run("maps/should resolve nested untyped maps", func(t *testing.T) {
+		expected := 5
+
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"var": nodes.Literal(map[string]any{
+					"attribute": map[string]any{
+						"value": expected,
+					},
+				}),
+			})
+		require.NoError(t, err)
+
+		gotValue, ok := scope.Definition("var.attribute.value")
+		require.True(t, ok)
+
+		require.Equal(t, expected, gotValue.Value())
+	})
+
+	t.Run("slices/should resolve indexes", func(t *testing.T) {
+		type item struct {
+			Name string `gon:"name"`
+		}
+
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"var": nodes.Literal(map[string]any{
+					"items": []item{{Name: "first"}, {Name: "second"}},
+				}),
+			})
+		require.NoError(t, err)
+
+		gotValue, ok := scope.Definition("var.items.1.name")
+		require.True(t, ok)
+		require.Equal(t, "second", gotValue.Value())
+
+		_, ok = scope.Definition("var.items.2.name")
+		require.False(t, ok)
+	})
+
+	t.Run("should not resolve fields of scalar values", func(t *testing.T) {
+		scope, err := gon.
+			NewScope().
+			WithValues(gon.Values{
+				"var": nodes.Literal(map[string]any{
+					"value": 5,
+				}),
+			})
+		require.NoError(t, err)
+
+		gotValue, ok := scope.Definition("var.value.field")
+		require.False(t, ok)
+
+		var notFound adapters.DefinitionNotFoundError
+		require.ErrorAs(t, gotValue.Value().(error), &notFound)
+		require.Equal(t, "value.field", notFound.DefinitionKey)
+	})
 }
 
 func Test_Literal_Call(t *testing.T) {
@@ -253,7 +314,7 @@ func Test_Literal_Encoding(t *testing.T) {
 
 	t.Run("should decode time", func(t *testing.T) {
 		require.NotPanics(t, func() {
-			t1 := time.Now().Truncate(time.Second).Round(0)
+			t1 := time.Now().UTC().Truncate(time.Second)
 			node := nodes.Literal(t1)
 			kns := node.Shape()
 
@@ -274,6 +335,34 @@ func Test_Literal_Encoding(t *testing.T) {
 		})
 	})
 
+	t.Run("should keep time nanoseconds", func(t *testing.T) {
+		t1 := time.Date(2024, time.May, 1, 12, 30, 0, 123456789, time.UTC)
+		node := nodes.Literal(t1)
+
+		kns := node.Shape()
+		require.Equal(t, "2024-05-01T12:30:00.123456789Z", kns[0].Node.(*nodes.LiteralNode).Value())
+
+		codex := make(encoding.Codex)
+		require.NoError(t, node.Register(&codex))
+
+		got, err := codex[node.Scalar()](kns)
+		require.NoError(t, err)
+		require.Equal(t, t1, got.(*nodes.LiteralNode).Value())
+	})
+
+	t.Run("should decode duration", func(t *testing.T) {
+		node := nodes.Literal(90 * time.Minute)
+		require.Equal(t, "duration", node.Scalar())
+		require.Equal(t, adapters.NodeTypeExpression, node.Type())
+
+		codex := make(encoding.Codex)
+		require.NoError(t, node.Register(&codex))
+
+		got, err := codex[node.Scalar()](node.Shape())
+		require.NoError(t, err)
+		require.Equal(t, 90*time.Minute, got.(*nodes.LiteralNode).Value())
+	})
+
 	t.Run("should decode string", func(t *testing.T) {
 		require.NotPanics(t, func() {
 			value := "value"
@@ -0,0 +1,62 @@
+package nodes
+
+import (
+	"strings"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+// localScope binds a value to a variable name, like the element of a collection, delegating every other definition to its parent.
+type localScope struct {
+	adapters.Scope
+	name  string
+	value adapters.Value
+}
+
+func newLocalScope(parent adapters.Scope, name string, value any) *localScope {
+	return &localScope{
+		Scope: parent,
+		name:  name,
+		value: Literal(value),
+	}
+}
+
+func (s *localScope) Definition(key string) (adapters.Value, bool) {
+	if key == s.name {
+		return s.value, true
+	}
+
+	if rest, ok := strings.CutPrefix(key, s.name+"."); ok {
+		reader, ok := s.value.(adapters.DefinitionReader)
+		if !ok {
+			return Literal(adapters.DefinitionNotFoundError{DefinitionKey: key}), false
+		}
+
+		return reader.Definition(rest)
+	}
+
+	return s.Scope.Definition(key)
+}
+
+func (s *localScope) Compute(node adapters.Node) (any, error) {
+	result := node.Eval(s)
+	if err, ok := result.Value().(error); ok {
+		return nil, err
+	}
+
+	return result.Value(), nil
+}
+
+func (s *localScope) Function(name string) (adapters.Callable, bool) {
+	reader, ok := s.Scope.(adapters.FunctionReader)
+	if !ok {
+		return nil, false
+	}
+
+	return reader.Function(name)
+}
+
+var (
+	_ adapters.Scope          = &localScope{}
+	_ adapters.FunctionReader = &localScope{}
+)
@@ -0,0 +1,21 @@
+package nodes
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_localScope_Definition(t *testing.T) {
+	t.Run("should return not found for attributes of values without definitions", func(t *testing.T) {
+		scope := &localScope{
+			name:  "it",
+			value: adapters.NewNodeError(Literal(1), adapters.ErrNumberOverflow),
+		}
+
+		got, ok := scope.Definition("it.price")
+		require.False(t, ok)
+		require.Equal(t, adapters.DefinitionNotFoundError{DefinitionKey: "it.price"}, got.Value())
+	})
+}
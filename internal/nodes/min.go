@@ -0,0 +1,81 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+	"github.com/sonalys/gon/internal/sliceutils"
+)
+
+type MinNode struct {
+	nodes []adapters.Node
+}
+
+// Min defines a node that evaluates to the smallest of its values.
+// Values are compared like equal and lt, so they should have the same type, except for integers compared with decimals.
+// Example: min(order.total, 100.0).
+func Min(nodes ...adapters.Node) adapters.Node {
+	if len(nodes) == 0 {
+		return adapters.NodeError{
+			NodeScalar: "min",
+			Cause:      adapters.ErrMustHaveArguments,
+		}
+	}
+
+	for i := range nodes {
+		if nodes[i] == nil {
+			return adapters.NodeError{
+				NodeScalar: "min",
+				Cause:      adapters.ErrAllNodesMustBeSet,
+			}
+		}
+	}
+
+	return &MinNode{
+		nodes: nodes,
+	}
+}
+
+func (node *MinNode) Scalar() string {
+	return "min"
+}
+
+func (node *MinNode) Shape() []adapters.KeyNode {
+	return sliceutils.Map(node.nodes, func(from adapters.Node) adapters.KeyNode { return adapters.KeyNode{Node: from} })
+}
+
+func (node *MinNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *MinNode) Eval(scope adapters.Scope) adapters.Value {
+	values := make([]any, 0, len(node.nodes))
+
+	for i := range node.nodes {
+		value, err := scope.Compute(node.nodes[i])
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		values = append(values, value)
+	}
+
+	result, err := extremumAny(-1, values...)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *MinNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		_, rest, err := gonutils.SortArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return Min(rest...), nil
+	})
+}
+
+var _ adapters.SerializableNode = &MinNode{}
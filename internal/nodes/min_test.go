@@ -0,0 +1,83 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Min(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should have at least one child", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Min())
+		require.Error(t, err)
+	})
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Min(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Min(nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should return the smallest value", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Min(nodes.Literal(3), nodes.Literal(1), nodes.Literal(2)))
+		require.NoError(t, err)
+		require.Equal(t, 1, got)
+	})
+
+	t.Run("should compare decimals with integers", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Min(nodes.Literal(decimal.MustParse("9.99")), nodes.Literal(int64(10))))
+		require.NoError(t, err)
+		require.Equal(t, decimal.MustParse("9.99"), got)
+	})
+
+	t.Run("should error on mixed types", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Min(nodes.Literal(1), nodes.Literal(1.5)))
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+	})
+
+	t.Run("should error on null", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Min(nodes.Literal(1), nodes.Literal(nil)))
+		require.ErrorIs(t, err, adapters.ErrNullOperand)
+	})
+}
+
+func Test_Min_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Min(nodes.Literal(1), nodes.Literal(2))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
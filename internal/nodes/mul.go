@@ -0,0 +1,80 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+	"github.com/sonalys/gon/internal/sliceutils"
+)
+
+type MulNode struct {
+	nodes []adapters.Node
+}
+
+// Mul defines a multiplication node, all input nodes should evaluate to the same numeric type.
+// Example: mul(item.price, item.quantity).
+func Mul(nodes ...adapters.Node) adapters.Node {
+	if len(nodes) == 0 {
+		return adapters.NodeError{
+			NodeScalar: "mul",
+			Cause:      adapters.ErrMustHaveArguments,
+		}
+	}
+
+	for i := range nodes {
+		if nodes[i] == nil {
+			return adapters.NodeError{
+				NodeScalar: "mul",
+				Cause:      adapters.ErrAllNodesMustBeSet,
+			}
+		}
+	}
+
+	return &MulNode{
+		nodes: nodes,
+	}
+}
+
+func (node *MulNode) Scalar() string {
+	return "mul"
+}
+
+func (node *MulNode) Shape() []adapters.KeyNode {
+	return sliceutils.Map(node.nodes, func(from adapters.Node) adapters.KeyNode { return adapters.KeyNode{Node: from} })
+}
+
+func (node *MulNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *MulNode) Eval(scope adapters.Scope) adapters.Value {
+	values := make([]any, 0, len(node.nodes))
+
+	for i := range node.nodes {
+		value, err := scope.Compute(node.nodes[i])
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		values = append(values, value)
+	}
+
+	product, err := arithmeticAny('*', values...)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(product)
+}
+
+func (node *MulNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		_, rest, err := gonutils.SortArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return Mul(rest...), nil
+	})
+}
+
+var _ adapters.SerializableNode = &MulNode{}
@@ -0,0 +1,106 @@
+package nodes_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Mul(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should have at least one child", func(t *testing.T) {
+		expr := nodes.Mul()
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		expr := nodes.Mul(nodes.Literal(assert.AnError))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("all children should be of the same type", func(t *testing.T) {
+		expr := nodes.Mul(nodes.Literal(2), nodes.Literal(3.))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, adapters.ErrAllNodesMustMatch)
+	})
+
+	t.Run("should error on non-numeric values", func(t *testing.T) {
+		expr := nodes.Mul(nodes.Literal("a"), nodes.Literal("b"))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+
+	t.Run("should multiply values", func(t *testing.T) {
+		expr := nodes.Mul(nodes.Literal(2), nodes.Literal(3), nodes.Literal(4))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 24, got)
+	})
+
+	t.Run("should error on integer overflow", func(t *testing.T) {
+		testCases := []struct {
+			first, second any
+		}{
+			{int64(math.MaxInt64), int64(2)},
+			{int64(-1), int64(math.MinInt64)},
+			{int64(math.MinInt64), int64(-1)},
+			{uint8(16), uint8(16)},
+		}
+
+		for _, tc := range testCases {
+			_, err := scope.Compute(nodes.Mul(nodes.Literal(tc.first), nodes.Literal(tc.second)))
+			require.ErrorIs(t, err, adapters.ErrNumberOverflow, "%v * %v", tc.first, tc.second)
+		}
+
+		got, err := scope.Compute(nodes.Mul(nodes.Literal(int64(math.MinInt64)), nodes.Literal(int64(1))))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64), got)
+
+		got, err = scope.Compute(nodes.Mul(nodes.Literal(int64(-3)), nodes.Literal(int64(0))))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), got)
+	})
+}
+
+func Test_Mul_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Mul(nodes.Literal(2), nodes.Literal(1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
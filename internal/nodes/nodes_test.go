@@ -14,18 +14,50 @@ func Test_Banner(t *testing.T) {
 		adapters.Shaped
 		adapters.Named
 	}{
+		&nodes.AbsNode{},
+		&nodes.AggregateNode{},
+		&nodes.AndNode{},
 		&nodes.AvgNode{},
 		&nodes.CallNode{},
+		&nodes.CeilNode{},
+		&nodes.ClampNode{},
+		&nodes.CondNode{},
+		&nodes.DistinctNode{},
+		&nodes.DivNode{},
 		&nodes.EqualNode{},
+		&nodes.ErrorNode{},
+		&nodes.FirstNode{},
+		&nodes.FlattenNode{},
+		&nodes.FloorNode{},
 		&nodes.GreaterNode{},
+		&nodes.GroupByNode{},
 		&nodes.HasPrefixNode{},
 		&nodes.HasSuffixNode{},
 		&nodes.IfNode{},
+		&nodes.ImpliesNode{},
+		&nodes.InNode{},
+		&nodes.IsErrorNode{},
+		&nodes.IsNullNode{},
 		&nodes.LiteralNode{},
+		&nodes.MaxNode{},
+		&nodes.MinNode{},
+		&nodes.MulNode{},
 		&nodes.NotNode{},
 		&nodes.OrNode{},
+		&nodes.PercentileOfNode{},
+		&nodes.PowNode{},
+		&nodes.RoundNode{},
+		&nodes.SetNode{},
+		&nodes.SliceNode{},
 		&nodes.SmallerNode{},
+		&nodes.SortNode{},
+		&nodes.SqrtNode{},
+		&nodes.SubNode{},
 		&nodes.SumNode{},
+		&nodes.SwitchNode{},
+		&nodes.ThresholdNode{},
+		&nodes.TryNode{},
+		&nodes.XorNode{},
 	}
 
 	for _, shaped := range shapedList {
@@ -1,8 +1,6 @@
 package nodes
 
 import (
-	"fmt"
-
 	"github.com/sonalys/gon/adapters"
 	"github.com/sonalys/gon/gonutils"
 )
@@ -45,7 +43,7 @@ func (node *NotNode) Eval(scope adapters.Scope) adapters.Value {
 
 	resp, ok := value.(bool)
 	if !ok {
-		return adapters.NewNodeError(node, fmt.Errorf("expected bool got %T", value))
+		return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "bool", Got: value})
 	}
 
 	return Literal(!resp)
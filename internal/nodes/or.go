@@ -68,7 +68,10 @@ func (node *OrNode) Eval(scope adapters.Scope) adapters.Value {
 
 func (node *OrNode) Register(codex adapters.Codex) error {
 	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
-		_, argsSlice, _ := gonutils.SortArgs(args)
+		_, argsSlice, err := gonutils.SortArgs(args)
+		if err != nil {
+			return nil, err
+		}
 
 		return Or(argsSlice...), nil
 	})
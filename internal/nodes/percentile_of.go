@@ -0,0 +1,111 @@
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type PercentileOfNode struct {
+	collection adapters.Node
+	percentile adapters.Node
+	variable   string
+	projection adapters.Node
+}
+
+// PercentileOf defines a node that evaluates to the percentile of a collection, or of their projections, from 0 to 100.
+// Values between the closest ranks are interpolated linearly, so the 50th percentile is the median.
+// Decimals keep their type, while integers and floats return a float64.
+// Example: percentileOf(orders, 95, it, it.total).
+func PercentileOf(collection, percentile adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	if percentile == nil {
+		return adapters.NodeError{
+			NodeScalar: "percentileOf",
+			Cause:      fmt.Errorf("percentile cannot be unset"),
+		}
+	}
+
+	if err := validateProjection(collection, variable, projection); err != nil {
+		return adapters.NodeError{
+			NodeScalar: "percentileOf",
+			Cause:      err,
+		}
+	}
+
+	return &PercentileOfNode{
+		collection: collection,
+		percentile: percentile,
+		variable:   variable,
+		projection: projection,
+	}
+}
+
+func (node *PercentileOfNode) Scalar() string {
+	return "percentileOf"
+}
+
+func (node *PercentileOfNode) Shape() []adapters.KeyNode {
+	shape := projectionShape(node.collection, node.variable, node.projection)
+
+	kv := []adapters.KeyNode{
+		shape[0],
+		{Key: "percentile", Node: node.percentile},
+	}
+
+	return append(kv, shape[1:]...)
+}
+
+func (node *PercentileOfNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *PercentileOfNode) Eval(scope adapters.Scope) adapters.Value {
+	rawPercentile, err := scope.Compute(node.percentile)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	percentile, ok := ConvertValue(rawPercentile, reflect.TypeFor[float64]())
+	if !ok || !isNumber(reflect.ValueOf(rawPercentile).Kind()) && !hasDecimal(rawPercentile) {
+		return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "number", Got: rawPercentile})
+	}
+
+	values, err := project(scope, node.collection, node.variable, node.projection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := percentileAny(percentile.Float(), values...)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *PercentileOfNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		// The variable and projection are optional, so only the given arguments are sorted.
+		keys := []string{"collection", "percentile", "variable", "projection"}
+
+		orderedArgs, rest, err := gonutils.SortArgs(args, keys[:min(len(args), len(keys))]...)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rest) > 0 {
+			return nil, fmt.Errorf("unexpected arguments, expected collection, percentile, variable and projection")
+		}
+
+		variable, projection, err := projectionArgs(orderedArgs)
+		if err != nil {
+			return nil, err
+		}
+
+		return PercentileOf(orderedArgs["collection"], orderedArgs["percentile"], variable, projection), nil
+	})
+}
+
+var _ adapters.SerializableNode = &PercentileOfNode{}
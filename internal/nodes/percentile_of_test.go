@@ -0,0 +1,104 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PercentileOf(t *testing.T) {
+	scope := gon.NewScope()
+
+	numbers := nodes.Literal([]int{15, 20, 35, 40, 50})
+
+	t.Run("should not have unset percentile", func(t *testing.T) {
+		_, err := scope.Compute(nodes.PercentileOf(numbers, nil, "", nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.PercentileOf(numbers, nodes.Literal(assert.AnError), "", nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should interpolate between ranks", func(t *testing.T) {
+		testCases := []struct {
+			percentile any
+			expected   float64
+		}{
+			{0, 15},
+			{50, 35},
+			{90, 46},
+			{int64(100), 50},
+			{decimal.MustParse("25"), 20},
+		}
+
+		for _, tc := range testCases {
+			got, err := scope.Compute(nodes.PercentileOf(numbers, nodes.Literal(tc.percentile), "", nil))
+			require.NoError(t, err)
+			require.InDelta(t, tc.expected, got, 1e-9, tc.percentile)
+		}
+	})
+
+	t.Run("should keep decimals", func(t *testing.T) {
+		prices := nodes.Literal([]decimal.Decimal{decimal.MustParse("10.00"), decimal.MustParse("20.00")})
+
+		got, err := scope.Compute(nodes.PercentileOf(prices, nodes.Literal(75), "", nil))
+		require.NoError(t, err)
+		require.Zero(t, got.(decimal.Decimal).Cmp(decimal.MustParse("17.5")))
+	})
+
+	t.Run("should apply the projection", func(t *testing.T) {
+		items := nodes.Literal([]cartItem{{Price: 1}, {Price: 3}})
+
+		got, err := scope.Compute(nodes.PercentileOf(items, nodes.Literal(50), "it", nodes.Reference("it.price")))
+		require.NoError(t, err)
+		require.Equal(t, 2.0, got)
+	})
+
+	t.Run("should error on invalid percentiles", func(t *testing.T) {
+		_, err := scope.Compute(nodes.PercentileOf(numbers, nodes.Literal(101), "", nil))
+		require.ErrorIs(t, err, adapters.ErrInvalidPercentile)
+
+		_, err = scope.Compute(nodes.PercentileOf(numbers, nodes.Literal("high"), "", nil))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+
+		_, err = scope.Compute(nodes.PercentileOf(nodes.Literal([]int{}), nodes.Literal(50), "", nil))
+		require.ErrorIs(t, err, adapters.ErrEmptyCollection)
+	})
+}
+
+func Test_PercentileOf_Encoding(t *testing.T) {
+	t.Run("should decode with projection", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.PercentileOf(nodes.Reference("orders"), nodes.Literal(int64(95)), "it", nodes.Reference("it.total"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type PowNode struct {
+	base     adapters.Node
+	exponent adapters.Node
+}
+
+// Pow defines a node that raises the base to the exponent, keeping the type of the base.
+// Floats accept any numeric exponent, while integers and decimals require an integer exponent.
+// Integer powers fail when they overflow, or with a negative exponent.
+// Example: pow(1.05, years).
+func Pow(base, exponent adapters.Node) adapters.Node {
+	if base == nil || exponent == nil {
+		return adapters.NodeError{
+			NodeScalar: "pow",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &PowNode{
+		base:     base,
+		exponent: exponent,
+	}
+}
+
+func (node *PowNode) Scalar() string {
+	return "pow"
+}
+
+func (node *PowNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "base", Node: node.base},
+		{Key: "exponent", Node: node.exponent},
+	}
+}
+
+func (node *PowNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *PowNode) Eval(scope adapters.Scope) adapters.Value {
+	base, err := scope.Compute(node.base)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	exponent, err := scope.Compute(node.exponent)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	power, err := powAny(base, exponent)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(power)
+}
+
+func (node *PowNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "base", "exponent")
+		if err != nil {
+			return nil, err
+		}
+
+		return Pow(orderedArgs["base"], orderedArgs["exponent"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &PowNode{}
@@ -0,0 +1,109 @@
+package nodes_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Pow(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Pow(nil, nodes.Literal(1)))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Pow(nodes.Literal(assert.AnError), nodes.Literal(1)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should raise integers exactly", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Pow(nodes.Literal(int64(3)), nodes.Literal(int64(4))))
+		require.NoError(t, err)
+		require.Equal(t, int64(81), got)
+	})
+
+	t.Run("should raise floats to any number", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Pow(nodes.Literal(4.0), nodes.Literal(0.5)))
+		require.NoError(t, err)
+		require.Equal(t, 2.0, got)
+	})
+
+	t.Run("should raise decimals to integers", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Pow(nodes.Literal(decimal.MustParse("1.1")), nodes.Literal(int64(2))))
+		require.NoError(t, err)
+		require.Equal(t, decimal.MustParse("1.21"), got)
+	})
+
+	t.Run("should error on integer overflow", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Pow(nodes.Literal(int8(2)), nodes.Literal(int64(7))))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		_, err = scope.Compute(nodes.Pow(nodes.Literal(int64(3)), nodes.Literal(int64(math.MaxInt64))))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		got, err := scope.Compute(nodes.Pow(nodes.Literal(int64(-2)), nodes.Literal(int64(63))))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64), got)
+
+		got, err = scope.Compute(nodes.Pow(nodes.Literal(uint8(1)), nodes.Literal(int64(math.MaxInt64))))
+		require.NoError(t, err)
+		require.Equal(t, uint8(1), got)
+	})
+
+	t.Run("should error on unbounded decimal powers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Pow(nodes.Literal(decimal.FromInt(2)), nodes.Literal(int64(math.MinInt64))))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		_, err = scope.Compute(nodes.Pow(nodes.Literal(decimal.MustParse("1.5")), nodes.Literal(int64(100000000))))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+	})
+
+	t.Run("should error on negative integer exponents", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Pow(nodes.Literal(int64(2)), nodes.Literal(int64(-1))))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+
+	t.Run("should error on fractional exponents for integers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Pow(nodes.Literal(int64(2)), nodes.Literal(0.5)))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Pow_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Pow(nodes.Literal(2), nodes.Literal(3))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/sonalys/gon/adapters"
@@ -31,6 +32,10 @@ func (node *ReferenceNode) Type() adapters.NodeType {
 func (node *ReferenceNode) Eval(scope adapters.Scope) adapters.Value {
 	value, ok := scope.Definition(node.definitionName)
 	if !ok {
+		if err, ok := providerError(value); ok {
+			return adapters.NewNodeError(node, err)
+		}
+
 		return adapters.NewNodeError(node, adapters.DefinitionNotFoundError{
 			DefinitionKey: node.definitionName,
 		})
@@ -39,6 +44,23 @@ func (node *ReferenceNode) Eval(scope adapters.Scope) adapters.Value {
 	return value.Eval(scope)
 }
 
+// providerError extracts a definition provider failure from a missing definition value.
+// Providers can fail for reasons other than a missing key, and those shouldn't be hidden.
+func providerError(value adapters.Value) (adapters.DefinitionProviderError, bool) {
+	var target adapters.DefinitionProviderError
+
+	if value == nil {
+		return target, false
+	}
+
+	err, ok := value.Value().(error)
+	if !ok {
+		return target, false
+	}
+
+	return target, errors.As(err, &target)
+}
+
 func (node *ReferenceNode) Register(codex adapters.Codex) error {
 	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
 		if len(args) != 1 {
@@ -0,0 +1,172 @@
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type RoundNode struct {
+	value  adapters.Node
+	places adapters.Node
+	mode   decimal.RoundingMode
+}
+
+// Round defines a node that rounds a number to the given number of digits after the point, using the rounding mode.
+// Decimals are rounded exactly, and keep the given number of places. Floats are rounded by their shortest decimal representation,
+// so round(2.675, 2) is 2.68. Integers are only changed by negative places, which round to tens, hundreds and so on.
+// The places default to 0 when unset.
+// Example: round(order.total, 2, "halfEven").
+func Round(value, places adapters.Node, mode decimal.RoundingMode) adapters.Node {
+	if value == nil {
+		return adapters.NodeError{
+			NodeScalar: "round",
+			Cause:      fmt.Errorf("value cannot be unset"),
+		}
+	}
+
+	if places == nil {
+		places = Literal(int64(0))
+	}
+
+	return &RoundNode{
+		value:  value,
+		places: places,
+		mode:   mode,
+	}
+}
+
+func (node *RoundNode) Scalar() string {
+	return "round"
+}
+
+func (node *RoundNode) Shape() []adapters.KeyNode {
+	kv := []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+		{Key: "places", Node: node.places},
+	}
+
+	if node.mode != decimal.HalfUp {
+		kv = append(kv, adapters.KeyNode{Key: "mode", Node: Literal(node.mode.String())})
+	}
+
+	return kv
+}
+
+func (node *RoundNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *RoundNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	rawPlaces, err := scope.Compute(node.places)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	places, ok := ConvertValue(rawPlaces, reflect.TypeFor[int32]())
+	if !ok {
+		return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "integer places", Got: rawPlaces})
+	}
+
+	rounded, err := roundAny(value, int32(places.Int()), node.mode)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(rounded)
+}
+
+// roundAny rounds decimals, floats and integers, keeping their type.
+// Places are limited to decimal.MaxDigits in both directions.
+func roundAny(value any, places int32, mode decimal.RoundingMode) (any, error) {
+	if isNull(value) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	if places > decimal.MaxDigits || places < -decimal.MaxDigits {
+		return nil, adapters.ErrInvalidPlaces
+	}
+
+	valueOf := reflect.ValueOf(value)
+
+	switch v := value.(type) {
+	case decimal.Decimal:
+		return v.Round(places, mode), nil
+	case float32, float64:
+		d, err := decimal.FromFloat(valueOf.Float())
+		if err != nil {
+			// Infinities and NaN are kept as they are.
+			return value, nil
+		}
+
+		return reflect.ValueOf(d.Round(places, mode).Float64()).Convert(valueOf.Type()).Interface(), nil
+	}
+
+	if !isNumber(valueOf.Kind()) {
+		return nil, adapters.UnexpectedTypeError{Expected: "number", Got: value}
+	}
+
+	if places >= 0 {
+		return value, nil
+	}
+
+	decimals, ok := castDecimals(value)
+	if !ok {
+		return nil, adapters.UnexpectedTypeError{Expected: "number", Got: value}
+	}
+
+	rounded := decimals[0].Round(places, mode).Rat().Num()
+
+	converted, ok := ConvertValue(rounded.Int64(), valueOf.Type())
+	if !rounded.IsInt64() || !ok {
+		return nil, adapters.TypeConversionError{Value: rounded, Target: valueOf.Type()}
+	}
+
+	return converted.Interface(), nil
+}
+
+func (node *RoundNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		// Places and mode are optional, so only the given arguments are sorted.
+		keys := []string{"value", "places", "mode"}
+
+		orderedArgs, rest, err := gonutils.SortArgs(args, keys[:min(len(args), len(keys))]...)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rest) > 0 {
+			return nil, fmt.Errorf("unexpected arguments, expected value, places and mode")
+		}
+
+		var mode decimal.RoundingMode
+
+		if modeNode, ok := orderedArgs["mode"]; ok {
+			valued, ok := modeNode.(adapters.Valued)
+			if !ok {
+				return nil, fmt.Errorf("expected string literal")
+			}
+
+			name, ok := valued.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", valued.Value())
+			}
+
+			if mode, err = decimal.ParseRoundingMode(name); err != nil {
+				return nil, err
+			}
+		}
+
+		return Round(orderedArgs["value"], orderedArgs["places"], mode), nil
+	})
+}
+
+var _ adapters.SerializableNode = &RoundNode{}
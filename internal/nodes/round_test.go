@@ -0,0 +1,113 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Round(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset value", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Round(nil, nil, decimal.HalfUp))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Round(nodes.Literal(assert.AnError), nil, decimal.HalfUp))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should round decimals to the places", func(t *testing.T) {
+		expr := nodes.Round(nodes.Literal(decimal.MustParse("2.345")), nodes.Literal(int64(2)), decimal.HalfEven)
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "2.34", got.(decimal.Decimal).String())
+	})
+
+	t.Run("should round floats by their decimal representation", func(t *testing.T) {
+		expr := nodes.Round(nodes.Literal(2.675), nodes.Literal(int64(2)), decimal.HalfUp)
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 2.68, got)
+	})
+
+	t.Run("should default to zero places", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Round(nodes.Literal(-2.5), nil, decimal.HalfUp))
+		require.NoError(t, err)
+		require.Equal(t, -3.0, got)
+	})
+
+	t.Run("should round integers to negative places", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Round(nodes.Literal(int64(1250)), nodes.Literal(int64(-2)), decimal.HalfEven))
+		require.NoError(t, err)
+		require.Equal(t, int64(1200), got)
+
+		got, err = scope.Compute(nodes.Round(nodes.Literal(int64(1250)), nodes.Literal(int64(2)), decimal.HalfEven))
+		require.NoError(t, err)
+		require.Equal(t, int64(1250), got)
+	})
+
+	t.Run("should error on invalid places", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Round(nodes.Literal(1.5), nodes.Literal(0.5), decimal.HalfUp))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+
+		_, err = scope.Compute(nodes.Round(nodes.Literal(decimal.MustParse("1.5")), nodes.Literal(int64(2000000000)), decimal.HalfUp))
+		require.ErrorIs(t, err, adapters.ErrInvalidPlaces)
+
+		_, err = scope.Compute(nodes.Round(nodes.Literal(1.5), nodes.Literal(int64(-2000000000)), decimal.HalfUp))
+		require.ErrorIs(t, err, adapters.ErrInvalidPlaces)
+	})
+
+	t.Run("should error on non numbers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Round(nodes.Literal("1.5"), nil, decimal.HalfUp))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Round_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Round(nodes.Literal(1.5), nodes.Literal(int64(1)), decimal.Floor)
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+
+	t.Run("should decode optional arguments", func(t *testing.T) {
+		node, err := encoding.Decode([]byte(`round(x)`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, nodes.Round(nodes.Reference("x"), nil, decimal.HalfUp), node)
+
+		_, err = encoding.Decode([]byte(`round(x, 2, "nearest")`), encoding.DefaultExpressionCodex)
+		require.Error(t, err)
+	})
+}
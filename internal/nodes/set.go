@@ -0,0 +1,205 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type SetNode struct {
+	scalar string
+	first  adapters.Node
+	second adapters.Node
+}
+
+// setScalars are the scalars of the set nodes, mapped to their constructors.
+var setScalars = map[string]func(first, second adapters.Node) adapters.Node{
+	"difference": Difference,
+	"intersect":  Intersect,
+	"isSubset":   IsSubset,
+	"overlaps":   Overlaps,
+	"union":      Union,
+}
+
+// Union defines a node that evaluates to the distinct elements of both collections, as a new slice.
+// Elements are compared like equal, and keep the order of their first occurrence.
+// Maps are sets of their keys, like map[string]bool{"admin": true}.
+// Example: union(user.roles, group.roles).
+func Union(first, second adapters.Node) adapters.Node {
+	return newSet("union", first, second)
+}
+
+// Intersect defines a node that evaluates to the distinct elements of the first collection that are in the second, as a new slice.
+// Example: intersect(user.roles, resource.roles).
+func Intersect(first, second adapters.Node) adapters.Node {
+	return newSet("intersect", first, second)
+}
+
+// Difference defines a node that evaluates to the distinct elements of the first collection that are not in the second, as a new slice.
+// Example: difference(resource.roles, user.roles).
+func Difference(first, second adapters.Node) adapters.Node {
+	return newSet("difference", first, second)
+}
+
+// IsSubset defines a node that evaluates to true when every element of the first collection is in the second.
+// Empty collections are subsets of any collection.
+// Example: isSubset(resource.roles, user.roles).
+func IsSubset(first, second adapters.Node) adapters.Node {
+	return newSet("isSubset", first, second)
+}
+
+// Overlaps defines a node that evaluates to true when any element of the first collection is in the second.
+// Example: overlaps(user.roles, resource.roles).
+func Overlaps(first, second adapters.Node) adapters.Node {
+	return newSet("overlaps", first, second)
+}
+
+func newSet(scalar string, first, second adapters.Node) adapters.Node {
+	if first == nil || second == nil {
+		return adapters.NodeError{
+			NodeScalar: scalar,
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &SetNode{
+		scalar: scalar,
+		first:  first,
+		second: second,
+	}
+}
+
+func (node *SetNode) Scalar() string {
+	return node.scalar
+}
+
+func (node *SetNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "first", Node: node.first},
+		{Key: "second", Node: node.second},
+	}
+}
+
+func (node *SetNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *SetNode) Eval(scope adapters.Scope) adapters.Value {
+	first, err := node.elements(scope, node.first)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	second, err := node.elements(scope, node.second)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := node.compute(first, second)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *SetNode) elements(scope adapters.Scope, collection adapters.Node) ([]any, error) {
+	value, err := scope.Compute(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if keys, ok := keysOf(value); ok {
+		return keys, nil
+	}
+
+	return elementsOf(value)
+}
+
+func (node *SetNode) compute(first, second []any) (any, error) {
+	switch node.scalar {
+	case "union":
+		return distinctOf(append(first, second...))
+	case "intersect":
+		return filterIn(first, second, true)
+	case "difference":
+		return filterIn(first, second, false)
+	case "isSubset":
+		for _, element := range first {
+			index, err := indexOf(element, second)
+			if err != nil || index < 0 {
+				return false, err
+			}
+		}
+
+		return true, nil
+	case "overlaps":
+		for _, element := range first {
+			index, err := indexOf(element, second)
+			if err != nil || index >= 0 {
+				return err == nil, err
+			}
+		}
+
+		return false, nil
+	default:
+		return nil, fmt.Errorf("unknown set operation '%s'", node.scalar)
+	}
+}
+
+// filterIn returns the distinct elements that are in other, or that are not in other.
+func filterIn(elements, other []any, in bool) ([]any, error) {
+	filtered := make([]any, 0, len(elements))
+
+	for _, element := range elements {
+		index, err := indexOf(element, other)
+		if err != nil {
+			return nil, err
+		}
+
+		if (index >= 0) == in {
+			filtered = append(filtered, element)
+		}
+	}
+
+	return distinctOf(filtered)
+}
+
+// distinctOf returns the distinct elements, keeping their first occurrence.
+func distinctOf(elements []any) ([]any, error) {
+	distinct := make([]any, 0, len(elements))
+
+	for _, element := range elements {
+		index, err := indexOf(element, distinct)
+		if err != nil {
+			return nil, err
+		}
+
+		if index < 0 {
+			distinct = append(distinct, element)
+		}
+	}
+
+	return distinct, nil
+}
+
+func (node *SetNode) Register(codex adapters.Codex) error {
+	for scalar, constructor := range setScalars {
+		err := codex.Register(scalar, func(args []adapters.KeyNode) (adapters.Node, error) {
+			orderedArgs, _, err := gonutils.SortArgs(args, "first", "second")
+			if err != nil {
+				return nil, err
+			}
+
+			return constructor(orderedArgs["first"], orderedArgs["second"]), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var _ adapters.SerializableNode = &SetNode{}
@@ -0,0 +1,123 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Set(t *testing.T) {
+	scope, err := gon.NewScope().
+		WithValues(gon.Values{
+			"user":     gon.Literal(map[string]any{"roles": []string{"viewer", "editor", "viewer"}}),
+			"required": gon.Literal(map[string]bool{"editor": true, "admin": false}),
+		})
+	require.NoError(t, err)
+
+	userRoles := nodes.Reference("user.roles")
+	required := nodes.Reference("required")
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Union(nil, userRoles))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Overlaps(nodes.Literal(assert.AnError), userRoles))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should combine collections", func(t *testing.T) {
+		testCases := []struct {
+			node     adapters.Node
+			expected any
+		}{
+			{nodes.Union(userRoles, required), []any{"viewer", "editor", "admin"}},
+			{nodes.Intersect(userRoles, required), []any{"editor"}},
+			{nodes.Difference(userRoles, required), []any{"viewer"}},
+			{nodes.Intersect(userRoles, nodes.Literal(nil)), []any{}},
+			{nodes.IsSubset(required, userRoles), false},
+			{nodes.IsSubset(nodes.Literal([]string{"editor"}), userRoles), true},
+			{nodes.IsSubset(nodes.Literal([]string{}), userRoles), true},
+			{nodes.Overlaps(userRoles, required), true},
+			{nodes.Overlaps(userRoles, nodes.Literal([]string{"owner"})), false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.node.Scalar(), func(t *testing.T) {
+				got, err := scope.Compute(tc.node)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, got)
+			})
+		}
+	})
+
+	t.Run("should use map keys as elements", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Union(required, nodes.Literal(map[string]int{"viewer": 1})))
+		require.NoError(t, err)
+		require.Equal(t, []any{"admin", "editor", "viewer"}, got)
+
+		got, err = scope.Compute(nodes.Overlaps(nodes.Literal([]string{"admin"}), required))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+
+	t.Run("should compare like equal", func(t *testing.T) {
+		prices := nodes.Literal([]any{decimal.MustParse("1.50"), int64(2)})
+
+		got, err := scope.Compute(nodes.IsSubset(nodes.Literal([]any{decimal.MustParse("1.5"), int64(2)}), prices))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+
+	t.Run("should error on mixed types", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Overlaps(userRoles, nodes.Literal([]int{1})))
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+	})
+
+	t.Run("should be decoded from text", func(t *testing.T) {
+		rule := `and(overlaps(user.roles, required), not(isSubset(required, user.roles)))`
+
+		node, err := encoding.Decode([]byte(rule), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		got, err := scope.Compute(node)
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+}
+
+func Test_Set_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Intersect(nodes.Reference("user.roles"), nodes.Reference("resource.roles"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
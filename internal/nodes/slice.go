@@ -0,0 +1,126 @@
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type SliceNode struct {
+	collection adapters.Node
+	start      adapters.Node
+	end        adapters.Node
+}
+
+// Slice defines a node that evaluates to the elements of a collection from the start index, up to the end index, excluded, as a new slice.
+// Negative indexes count from the end, and indexes out of range are clamped, so slice(items, -3) are the last 3 elements.
+// The end defaults to the length of the collection when unset.
+// Example: slice(sortDesc(cart.items, it, it.price), 0, 3).
+func Slice(collection, start, end adapters.Node) adapters.Node {
+	if collection == nil || start == nil {
+		return adapters.NodeError{
+			NodeScalar: "slice",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &SliceNode{
+		collection: collection,
+		start:      start,
+		end:        end,
+	}
+}
+
+func (node *SliceNode) Scalar() string {
+	return "slice"
+}
+
+func (node *SliceNode) Shape() []adapters.KeyNode {
+	kv := []adapters.KeyNode{
+		{Key: "collection", Node: node.collection},
+		{Key: "start", Node: node.start},
+	}
+
+	if node.end != nil {
+		kv = append(kv, adapters.KeyNode{Key: "end", Node: node.end})
+	}
+
+	return kv
+}
+
+func (node *SliceNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *SliceNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	start, err := sliceIndex(scope, node.start, len(elements))
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	end := len(elements)
+
+	if node.end != nil {
+		if end, err = sliceIndex(scope, node.end, len(elements)); err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+	}
+
+	if start >= end {
+		return Literal([]any{})
+	}
+
+	return Literal(elements[start:end])
+}
+
+// sliceIndex evaluates the index, counting negative indexes from the end, and clamping it to the length.
+func sliceIndex(scope adapters.Scope, indexNode adapters.Node, length int) (int, error) {
+	rawIndex, err := scope.Compute(indexNode)
+	if err != nil {
+		return 0, err
+	}
+
+	index, ok := ConvertValue(rawIndex, reflect.TypeFor[int]())
+	if !ok {
+		return 0, adapters.UnexpectedTypeError{Expected: "integer index", Got: rawIndex}
+	}
+
+	position := int(index.Int())
+	if position < 0 {
+		position += length
+	}
+
+	return min(max(position, 0), length), nil
+}
+
+func (node *SliceNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		// The end is optional, so only the given arguments are sorted.
+		keys := []string{"collection", "start", "end"}
+
+		orderedArgs, rest, err := gonutils.SortArgs(args, keys[:min(len(args), len(keys))]...)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rest) > 0 {
+			return nil, fmt.Errorf("unexpected arguments, expected collection, start and end")
+		}
+
+		return Slice(orderedArgs["collection"], orderedArgs["start"], orderedArgs["end"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &SliceNode{}
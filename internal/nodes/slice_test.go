@@ -0,0 +1,82 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Slice(t *testing.T) {
+	scope := gon.NewScope()
+
+	collection := nodes.Literal([]int{1, 2, 3, 4, 5})
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Slice(collection, nil, nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Slice(collection, nodes.Literal(assert.AnError), nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should slice between indexes", func(t *testing.T) {
+		testCases := []struct {
+			start, end adapters.Node
+			expected   []any
+		}{
+			{nodes.Literal(0), nodes.Literal(int64(3)), []any{1, 2, 3}},
+			{nodes.Literal(3), nil, []any{4, 5}},
+			{nodes.Literal(-2), nil, []any{4, 5}},
+			{nodes.Literal(1), nodes.Literal(-1), []any{2, 3, 4}},
+			{nodes.Literal(-10), nodes.Literal(10), []any{1, 2, 3, 4, 5}},
+			{nodes.Literal(4), nodes.Literal(2), []any{}},
+		}
+
+		for _, tc := range testCases {
+			got, err := scope.Compute(nodes.Slice(collection, tc.start, tc.end))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+		}
+	})
+
+	t.Run("should error on invalid indexes", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Slice(collection, nodes.Literal(1.5), nil))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Slice_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Slice(nodes.Reference("orders"), nodes.Literal(int64(0)), nodes.Literal(int64(3)))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -81,6 +81,10 @@ func (node *SmallerNode) Eval(scope adapters.Scope) adapters.Value {
 		return adapters.NewNodeError(node, err)
 	}
 
+	if hasNull(firstValue, secondValue) {
+		return adapters.NewNodeError(node, adapters.ErrNullOperand)
+	}
+
 	comparison, ok := cmpAny(firstValue, secondValue)
 	if !ok {
 		return adapters.NewNodeError(node, adapters.IncompatiblePairError{
@@ -113,7 +117,7 @@ func (node *SmallerNode) Register(codex adapters.Codex) error {
 		if err != nil {
 			return nil, err
 		}
-		return Smaller(orderedArgs["first"], orderedArgs["second"]), nil
+		return SmallerOrEqual(orderedArgs["first"], orderedArgs["second"]), nil
 	})
 }
 
@@ -144,4 +144,31 @@ func Test_Smaller_Encoding(t *testing.T) {
 			require.Equal(t, node, got)
 		})
 	})
+
+	t.Run("should decode inclusive with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.SmallerOrEqual(nodes.Literal(true), nodes.Literal(1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
 }
@@ -0,0 +1,146 @@
+package nodes
+
+import (
+	"slices"
+
+	"github.com/sonalys/gon/adapters"
+)
+
+type SortNode struct {
+	collection adapters.Node
+	variable   string
+	projection adapters.Node
+	descending bool
+}
+
+// Sort defines a node that sorts a collection into a new slice, in ascending order of its elements or of their projections.
+// The sort is stable, and compares values like lt, so they should have the same type, except for integers compared with decimals.
+// Example: sort(orders, it, it.createdAt).
+func Sort(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newSort("sort", collection, variable, projection, false)
+}
+
+// SortDesc defines a node like Sort, in descending order.
+// Example: slice(sortDesc(cart.items, it, it.price), 0, 3).
+func SortDesc(collection adapters.Node, variable string, projection adapters.Node) adapters.Node {
+	return newSort("sortDesc", collection, variable, projection, true)
+}
+
+func newSort(scalar string, collection adapters.Node, variable string, projection adapters.Node, descending bool) adapters.Node {
+	if err := validateProjection(collection, variable, projection); err != nil {
+		return adapters.NodeError{
+			NodeScalar: scalar,
+			Cause:      err,
+		}
+	}
+
+	return &SortNode{
+		collection: collection,
+		variable:   variable,
+		projection: projection,
+		descending: descending,
+	}
+}
+
+func (node *SortNode) Scalar() string {
+	if node.descending {
+		return "sortDesc"
+	}
+
+	return "sort"
+}
+
+func (node *SortNode) Shape() []adapters.KeyNode {
+	return projectionShape(node.collection, node.variable, node.projection)
+}
+
+func (node *SortNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *SortNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.collection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	elements, err := elementsOf(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	keys, err := projectElements(scope, elements, node.variable, node.projection)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	sorted, err := sortAny(elements, keys, node.descending)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(sorted)
+}
+
+// sortAny returns the elements stably sorted by their keys, compared like cmpAny.
+func sortAny(elements, keys []any, descending bool) ([]any, error) {
+	if hasNull(keys...) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	order := make([]int, len(elements))
+	for i := range order {
+		order[i] = i
+	}
+
+	var err error
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		comparison, ok := cmpAny(keys[a], keys[b])
+		if !ok && err == nil {
+			err = adapters.IncompatiblePairError{First: keys[a], Second: keys[b]}
+		}
+
+		if descending {
+			return -comparison
+		}
+
+		return comparison
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]any, 0, len(elements))
+	for _, i := range order {
+		sorted = append(sorted, elements[i])
+	}
+
+	return sorted, nil
+}
+
+func (node *SortNode) Register(codex adapters.Codex) error {
+	err := codex.Register("sort", func(args []adapters.KeyNode) (adapters.Node, error) {
+		collection, variable, projection, err := sortProjectionArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return Sort(collection, variable, projection), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = codex.Register("sortDesc", func(args []adapters.KeyNode) (adapters.Node, error) {
+		collection, variable, projection, err := sortProjectionArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return SortDesc(collection, variable, projection), nil
+	})
+
+	return err
+}
+
+var _ adapters.SerializableNode = &SortNode{}
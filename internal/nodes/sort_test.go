@@ -0,0 +1,100 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Sort(t *testing.T) {
+	scope := gon.NewScope()
+
+	items := nodes.Literal([]cartItem{
+		{Name: "book", Price: 12.5},
+		{Name: "pen", Price: 2.5},
+		{Name: "lamp", Price: 30},
+		{Name: "mug", Price: 2.5},
+	})
+
+	names := func(t *testing.T, got any) []string {
+		t.Helper()
+
+		sorted, ok := got.([]any)
+		require.True(t, ok)
+
+		result := make([]string, 0, len(sorted))
+		for _, item := range sorted {
+			result = append(result, item.(cartItem).Name)
+		}
+
+		return result
+	}
+
+	t.Run("should not have unset collection", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sort(nil, "", nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sort(nodes.Literal(assert.AnError), "", nil))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should sort elements", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Sort(nodes.Literal([]int{3, 1, 2}), "", nil))
+		require.NoError(t, err)
+		require.Equal(t, []any{1, 2, 3}, got)
+	})
+
+	t.Run("should sort stably by the projection", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Sort(items, "it", nodes.Reference("it.price")))
+		require.NoError(t, err)
+		require.Equal(t, []string{"pen", "mug", "book", "lamp"}, names(t, got))
+
+		got, err = scope.Compute(nodes.SortDesc(items, "it", nodes.Reference("it.price")))
+		require.NoError(t, err)
+		require.Equal(t, []string{"lamp", "book", "pen", "mug"}, names(t, got))
+	})
+
+	t.Run("should error on mixed types", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sort(nodes.Literal([]any{1, "a"}), "", nil))
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+
+		_, err = scope.Compute(nodes.Sort(nodes.Literal([]any{1, nil}), "", nil))
+		require.ErrorIs(t, err, adapters.ErrNullOperand)
+	})
+}
+
+func Test_Sort_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.SortDesc(nodes.Reference("cart.items"), "it", nodes.Reference("it.price"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
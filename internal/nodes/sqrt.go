@@ -0,0 +1,69 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type SqrtNode struct {
+	value adapters.Node
+}
+
+// Sqrt defines a node that evaluates to the square root of a number.
+// Decimals evaluate to decimals, like decimal.Decimal.Sqrt, and other numbers to float64. Negative numbers fail.
+// Example: sqrt(sum(mul(x, x), mul(y, y))).
+func Sqrt(value adapters.Node) adapters.Node {
+	if value == nil {
+		return adapters.NodeError{
+			NodeScalar: "sqrt",
+			Cause:      fmt.Errorf("value cannot be unset"),
+		}
+	}
+
+	return &SqrtNode{
+		value: value,
+	}
+}
+
+func (node *SqrtNode) Scalar() string {
+	return "sqrt"
+}
+
+func (node *SqrtNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "value", Node: node.value},
+	}
+}
+
+func (node *SqrtNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *SqrtNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	result, err := sqrtAny(value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(result)
+}
+
+func (node *SqrtNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "value")
+		if err != nil {
+			return nil, err
+		}
+
+		return Sqrt(orderedArgs["value"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &SqrtNode{}
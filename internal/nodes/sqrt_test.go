@@ -0,0 +1,78 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Sqrt(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sqrt(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sqrt(nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should return floats for integers", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Sqrt(nodes.Literal(int64(9))))
+		require.NoError(t, err)
+		require.Equal(t, 3.0, got)
+	})
+
+	t.Run("should work for decimals", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Sqrt(nodes.Literal(decimal.MustParse("6.25"))))
+		require.NoError(t, err)
+		require.Equal(t, decimal.MustParse("2.50"), got)
+	})
+
+	t.Run("should error on negative numbers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sqrt(nodes.Literal(-1.0)))
+		require.ErrorIs(t, err, adapters.ErrNegativeRoot)
+	})
+
+	t.Run("should error on non numbers", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Sqrt(nodes.Literal("4")))
+		require.ErrorAs(t, err, &adapters.UnexpectedTypeError{})
+	})
+}
+
+func Test_Sqrt_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Sqrt(nodes.Literal(4.0))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type SubNode struct {
+	first  adapters.Node
+	second adapters.Node
+}
+
+// Sub defines a subtraction node, all input nodes should evaluate to the same numeric type.
+// Returns the first value minus the second.
+// Example: sub(order.total, order.discount).
+func Sub(first, second adapters.Node) adapters.Node {
+	if first == nil || second == nil {
+		return adapters.NodeError{
+			NodeScalar: "sub",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &SubNode{
+		first:  first,
+		second: second,
+	}
+}
+
+func (node *SubNode) Scalar() string {
+	return "sub"
+}
+
+func (node *SubNode) Shape() []adapters.KeyNode {
+	return []adapters.KeyNode{
+		{Key: "first", Node: node.first},
+		{Key: "second", Node: node.second},
+	}
+}
+
+func (node *SubNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *SubNode) Eval(scope adapters.Scope) adapters.Value {
+	firstValue, err := scope.Compute(node.first)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	secondValue, err := scope.Compute(node.second)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	difference, err := arithmeticAny('-', firstValue, secondValue)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(difference)
+}
+
+func (node *SubNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, _, err := gonutils.SortArgs(args, "first", "second")
+		if err != nil {
+			return nil, err
+		}
+
+		return Sub(orderedArgs["first"], orderedArgs["second"]), nil
+	})
+}
+
+var _ adapters.SerializableNode = &SubNode{}
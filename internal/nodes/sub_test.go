@@ -0,0 +1,94 @@
+package nodes_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Sub(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		expr := nodes.Sub(nodes.Literal(1), nil)
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		expr := nodes.Sub(nodes.Literal(1), nodes.Literal(assert.AnError))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("all children should be of the same type", func(t *testing.T) {
+		expr := nodes.Sub(nodes.Literal(int64(1)), nodes.Literal(1))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, adapters.ErrAllNodesMustMatch)
+	})
+
+	t.Run("should subtract values", func(t *testing.T) {
+		expr := nodes.Sub(nodes.Literal(10.5), nodes.Literal(0.5))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 10.0, got)
+	})
+
+	t.Run("should error on integer overflow", func(t *testing.T) {
+		testCases := []struct {
+			first, second any
+		}{
+			{int64(math.MinInt64), int64(1)},
+			{int64(math.MaxInt64), int64(-1)},
+			{uint(1), uint(2)},
+		}
+
+		for _, tc := range testCases {
+			_, err := scope.Compute(nodes.Sub(nodes.Literal(tc.first), nodes.Literal(tc.second)))
+			require.ErrorIs(t, err, adapters.ErrNumberOverflow, "%v - %v", tc.first, tc.second)
+		}
+
+		got, err := scope.Compute(nodes.Sub(nodes.Literal(int64(-1)), nodes.Literal(int64(math.MaxInt64))))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64), got)
+	})
+}
+
+func Test_Sub_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Sub(nodes.Literal(2), nodes.Literal(1))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
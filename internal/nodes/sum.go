@@ -56,6 +56,10 @@ func (node *SumNode) Eval(scope adapters.Scope) adapters.Value {
 		values = append(values, value)
 	}
 
+	if hasNull(values...) {
+		return adapters.NewNodeError(node, adapters.ErrNullOperand)
+	}
+
 	sum, ok := sumAny(values...)
 	if !ok {
 		return adapters.NewNodeError(node, adapters.ErrAllNodesMustMatch)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/sonalys/gon"
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
 	"github.com/sonalys/gon/encoding"
 	"github.com/sonalys/gon/internal/nodes"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,29 @@ func Test_Sum(t *testing.T) {
 		require.ErrorAs(t, err, &adapters.NodeError{})
 	})
 
+	t.Run("should not sum null", func(t *testing.T) {
+		expr := nodes.Sum(nodes.Literal(1), nodes.Literal(nil))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, adapters.ErrNullOperand)
+		require.Equal(t, adapters.CodeTypeMismatch, adapters.CodeOf(err))
+	})
+
+	t.Run("should sum decimals exactly", func(t *testing.T) {
+		expr := nodes.Sum(nodes.Literal(decimal.MustParse("0.1")), nodes.Literal(decimal.MustParse("0.2")), nodes.Literal(int64(1)))
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "1.3", got.(decimal.Decimal).String())
+	})
+
+	t.Run("should not sum decimals with floats", func(t *testing.T) {
+		expr := nodes.Sum(nodes.Literal(decimal.MustParse("0.1")), nodes.Literal(0.2))
+
+		_, err := scope.Compute(expr)
+		require.ErrorIs(t, err, adapters.ErrAllNodesMustMatch)
+	})
+
 	t.Run("all children should be of the same type", func(t *testing.T) {
 		expr := nodes.Sum(nodes.Literal(1), nodes.Literal(1.))
 
@@ -0,0 +1,181 @@
+package nodes
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type SwitchNode struct {
+	value         adapters.Node
+	cases         []adapters.KeyNode
+	defaultBranch adapters.Node
+}
+
+// Case defines a case argument for switch and cond.
+// The result is evaluated when the case matches.
+func Case(match, result adapters.Node) adapters.KeyNode {
+	return adapters.KeyNode{Case: match, Node: result}
+}
+
+// Default defines the default argument for switch and cond, evaluated when no case matches.
+func Default(result adapters.Node) adapters.KeyNode {
+	return adapters.KeyNode{Key: "default", Node: result}
+}
+
+// Switch defines a multi-way branching node.
+// It evaluates the result of the first case equal to the value, or the default result when no case matches.
+// Values and cases should evaluate to the same type.
+// Example: switch(user.tier, "gold": 0.2, "silver": 0.1, default: 0).
+func Switch(value adapters.Node, cases ...adapters.KeyNode) adapters.Node {
+	if value == nil {
+		return adapters.NodeError{
+			NodeScalar: "switch",
+			Cause:      fmt.Errorf("value cannot be unset"),
+		}
+	}
+
+	caseArgs, defaultBranch, err := splitDefault(cases)
+	if err != nil {
+		return adapters.NodeError{
+			NodeScalar: "switch",
+			Cause:      err,
+		}
+	}
+
+	return &SwitchNode{
+		value:         value,
+		cases:         caseArgs,
+		defaultBranch: defaultBranch,
+	}
+}
+
+func (node *SwitchNode) Scalar() string {
+	return "switch"
+}
+
+func (node *SwitchNode) Shape() []adapters.KeyNode {
+	kv := make([]adapters.KeyNode, 0, len(node.cases)+2)
+	kv = append(kv, adapters.KeyNode{Key: "value", Node: node.value})
+	kv = append(kv, node.cases...)
+
+	if node.defaultBranch != nil {
+		kv = append(kv, Default(node.defaultBranch))
+	}
+
+	return kv
+}
+
+func (node *SwitchNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *SwitchNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.value)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	for _, arg := range node.cases {
+		match, err := scope.Compute(arg.Case)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		equal, ok := equalAny(value, match)
+		if !ok {
+			return adapters.NewNodeError(node, adapters.IncompatiblePairError{
+				First:  value,
+				Second: match,
+			})
+		}
+
+		if equal {
+			return evalBranch(node, scope, arg.Node)
+		}
+	}
+
+	if node.defaultBranch != nil {
+		return evalBranch(node, scope, node.defaultBranch)
+	}
+
+	return adapters.NewNodeError(node, fmt.Errorf("no case matched %v", value))
+}
+
+func (node *SwitchNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		cases, rest := gonutils.SplitCases(args)
+
+		if err := checkBranchKeys(rest, "value"); err != nil {
+			return nil, err
+		}
+
+		orderedArgs, defaults, err := gonutils.SortArgs(rest, "value")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(defaults) > 1 {
+			return nil, fmt.Errorf("only one default can be set")
+		}
+
+		for _, defaultBranch := range defaults {
+			cases = append(cases, Default(defaultBranch))
+		}
+
+		return Switch(orderedArgs["value"], cases...), nil
+	})
+}
+
+// checkBranchKeys rejects named arguments other than the keys and default, which would otherwise be taken as the default.
+func checkBranchKeys(args []adapters.KeyNode, keys ...string) error {
+	for _, arg := range args {
+		if arg.Key != "" && arg.Key != "default" && !slices.Contains(keys, arg.Key) {
+			return fmt.Errorf("unexpected argument '%s', expected a case or default", arg.Key)
+		}
+	}
+
+	return nil
+}
+
+// splitDefault separates the default argument from the cases.
+func splitDefault(args []adapters.KeyNode) ([]adapters.KeyNode, adapters.Node, error) {
+	cases := make([]adapters.KeyNode, 0, len(args))
+
+	var defaultBranch adapters.Node
+
+	for _, arg := range args {
+		switch {
+		case arg.Case != nil:
+			if arg.Node == nil {
+				return nil, nil, fmt.Errorf("case result cannot be unset")
+			}
+
+			cases = append(cases, arg)
+		case arg.Key != "default":
+			return nil, nil, fmt.Errorf("unexpected argument '%s', expected a case or default", arg.Key)
+		case defaultBranch != nil:
+			return nil, nil, fmt.Errorf("only one default can be set")
+		case arg.Node == nil:
+			return nil, nil, fmt.Errorf("default cannot be unset")
+		default:
+			defaultBranch = arg.Node
+		}
+	}
+
+	return cases, defaultBranch, nil
+}
+
+// evalBranch evaluates the selected branch of a branching node.
+func evalBranch(node adapters.Named, scope adapters.Scope, branch adapters.Node) adapters.Value {
+	value, err := scope.Compute(branch)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return Literal(value)
+}
+
+var _ adapters.SerializableNode = &SwitchNode{}
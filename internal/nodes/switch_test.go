@@ -0,0 +1,181 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Switch(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("value should not be unset", func(t *testing.T) {
+		expr := nodes.Switch(nil, nodes.Default(nodes.Literal(1)))
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should error on multiple defaults", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(1), nodes.Default(nodes.Literal(1)), nodes.Default(nodes.Literal(2)))
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should error on arguments without case", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(1), adapters.KeyNode{Key: "other", Node: nodes.Literal(1)})
+
+		_, err := scope.Compute(expr)
+		require.Error(t, err)
+	})
+
+	t.Run("should compute the first matching case", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal("silver"),
+			nodes.Case(nodes.Literal("gold"), nodes.Literal(0.2)),
+			nodes.Case(nodes.Literal("silver"), nodes.Literal(0.1)),
+			nodes.Case(nodes.Literal("silver"), nodes.Literal(0.3)),
+			nodes.Default(nodes.Literal(0.0)),
+		)
+
+		value, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 0.1, value)
+	})
+
+	t.Run("should compute default if no case matches", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal("bronze"),
+			nodes.Case(nodes.Literal("gold"), nodes.Literal(0.2)),
+			nodes.Default(nodes.Literal(0.0)),
+		)
+
+		value, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, 0.0, value)
+	})
+
+	t.Run("should not compute unmatched cases", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(1),
+			nodes.Case(nodes.Literal(1), nodes.Literal(true)),
+			nodes.Case(nodes.Literal(2), nodes.Literal(assert.AnError)),
+		)
+
+		value, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.True(t, value.(bool))
+	})
+
+	t.Run("should error if no case matches without default", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(1), nodes.Case(nodes.Literal(2), nodes.Literal(true)))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should match null cases", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(nil),
+			nodes.Case(nodes.Literal(1), nodes.Literal("one")),
+			nodes.Case(nodes.Literal(nil), nodes.Literal("null")),
+		)
+
+		got, err := scope.Compute(expr)
+		require.NoError(t, err)
+		require.Equal(t, "null", got)
+	})
+
+	t.Run("should error on incompatible cases", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(1), nodes.Case(nodes.Literal("1"), nodes.Literal(true)))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.IncompatiblePairError{})
+	})
+
+	t.Run("should propagate value error", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(assert.AnError), nodes.Default(nodes.Literal(true)))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should propagate case error", func(t *testing.T) {
+		expr := nodes.Switch(nodes.Literal(1), nodes.Case(nodes.Literal(assert.AnError), nodes.Literal(true)))
+
+		_, err := scope.Compute(expr)
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+}
+
+func Test_Switch_Encoding(t *testing.T) {
+	t.Run("should decode with cases and default", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Switch(nodes.Reference("tier"),
+				nodes.Case(nodes.Literal("gold"), nodes.Literal(0.2)),
+				nodes.Default(nodes.Literal(0.0)),
+			)
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+
+	t.Run("should decode unnamed value and default", func(t *testing.T) {
+		node, err := encoding.Decode([]byte(`switch(tier, "gold": 0.2, "silver": 0.1, 0.5)`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		for tier, expected := range map[string]float64{"gold": 0.2, "silver": 0.1, "bronze": 0.5} {
+			scope, err := gon.NewScope().WithValues(gon.Values{"tier": nodes.Literal(tier)})
+			require.NoError(t, err)
+
+			value, err := scope.Compute(node)
+			require.NoError(t, err)
+			require.Equal(t, expected, value)
+		}
+	})
+
+	t.Run("should decode references as cases", func(t *testing.T) {
+		node, err := encoding.Decode([]byte(`switch(tier, gold: 1, default: 0)`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		scope, err := gon.NewScope().WithValues(gon.Values{"tier": nodes.Literal("a"), "gold": nodes.Literal("a")})
+		require.NoError(t, err)
+
+		value, err := scope.Compute(node)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), value)
+	})
+
+	t.Run("should reject unknown named arguments", func(t *testing.T) {
+		codex := make(encoding.Codex)
+		require.NoError(t, (&nodes.SwitchNode{}).Register(&codex))
+
+		_, err := codex["switch"]([]adapters.KeyNode{
+			{Key: "value", Node: nodes.Reference("tier")},
+			nodes.Case(nodes.Literal("gold"), nodes.Literal(1)),
+			{Key: "foo", Node: nodes.Literal(0)},
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+package nodes
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type ThresholdNode struct {
+	scalar string
+	count  adapters.Node
+	nodes  []adapters.Node
+}
+
+// thresholdScalars are the scalars of the threshold nodes, mapped to their constructors.
+var thresholdScalars = map[string]func(count adapters.Node, nodes ...adapters.Node) adapters.Node{
+	"atLeast": AtLeast,
+	"atMost":  AtMost,
+	"exactly": Exactly,
+}
+
+// AtLeast defines a node that is true when at least count of its inputs are true.
+// Inputs are evaluated in order, until the result is known.
+// Like or, it returns the value of the first error or non-boolean expression evaluated.
+// Example: atLeast(2, kyc.verified, kyc.address, kyc.phone, kyc.email).
+func AtLeast(count adapters.Node, nodes ...adapters.Node) adapters.Node {
+	return newThreshold("atLeast", count, nodes...)
+}
+
+// AtMost defines a node that is true when at most count of its inputs are true, evaluating them like AtLeast.
+// Example: atMost(1, flags.fraud, flags.chargeback, flags.dispute).
+func AtMost(count adapters.Node, nodes ...adapters.Node) adapters.Node {
+	return newThreshold("atMost", count, nodes...)
+}
+
+// Exactly defines a node that is true when exactly count of its inputs are true, evaluating them like AtLeast.
+// Example: exactly(1, payment.card, payment.pix, payment.invoice).
+func Exactly(count adapters.Node, nodes ...adapters.Node) adapters.Node {
+	return newThreshold("exactly", count, nodes...)
+}
+
+func newThreshold(scalar string, count adapters.Node, nodes ...adapters.Node) adapters.Node {
+	if len(nodes) == 0 {
+		return adapters.NodeError{
+			NodeScalar: scalar,
+			Cause:      adapters.ErrMustHaveArguments,
+		}
+	}
+
+	if count == nil || slices.Contains(nodes, nil) {
+		return adapters.NodeError{
+			NodeScalar: scalar,
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	return &ThresholdNode{
+		scalar: scalar,
+		count:  count,
+		nodes:  nodes,
+	}
+}
+
+func (node *ThresholdNode) Scalar() string {
+	return node.scalar
+}
+
+func (node *ThresholdNode) Shape() []adapters.KeyNode {
+	kv := make([]adapters.KeyNode, 0, len(node.nodes)+1)
+	kv = append(kv, adapters.KeyNode{Node: node.count})
+
+	for i := range node.nodes {
+		kv = append(kv, adapters.KeyNode{Node: node.nodes[i]})
+	}
+
+	return kv
+}
+
+func (node *ThresholdNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *ThresholdNode) Eval(scope adapters.Scope) adapters.Value {
+	rawCount, err := scope.Compute(node.count)
+	if err != nil {
+		return adapters.NewNodeError(node, err)
+	}
+
+	// Counts must be integral and not negative, like 2 or 2.0.
+	count, ok := ConvertValue(rawCount, reflect.TypeFor[int]())
+	if !ok || count.Int() < 0 {
+		return adapters.NewNodeError(node, adapters.UnexpectedTypeError{Expected: "integer count", Got: rawCount})
+	}
+
+	trues := 0
+
+	for i, expr := range node.nodes {
+		if result, ok := node.decide(int(count.Int()), trues, len(node.nodes)-i); ok {
+			return Literal(result)
+		}
+
+		value, err := scope.Compute(expr)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		switch value := value.(type) {
+		case bool:
+			if value {
+				trues++
+			}
+		default:
+			return Literal(value)
+		}
+	}
+
+	result, _ := node.decide(int(count.Int()), trues, 0)
+
+	return Literal(result)
+}
+
+// decide returns the result, if it is known from the number of true inputs and the number of remaining inputs.
+func (node *ThresholdNode) decide(count, trues, remaining int) (bool, bool) {
+	switch node.scalar {
+	case "atLeast":
+		switch {
+		case trues >= count:
+			return true, true
+		case trues+remaining < count:
+			return false, true
+		}
+	case "atMost":
+		switch {
+		case trues > count:
+			return false, true
+		case trues+remaining <= count:
+			return true, true
+		}
+	case "exactly":
+		switch {
+		case trues > count, trues+remaining < count:
+			return false, true
+		case remaining == 0:
+			return trues == count, true
+		}
+	}
+
+	return false, false
+}
+
+func (node *ThresholdNode) Register(codex adapters.Codex) error {
+	for scalar, constructor := range thresholdScalars {
+		err := codex.Register(scalar, func(args []adapters.KeyNode) (adapters.Node, error) {
+			orderedArgs, rest, err := gonutils.SortArgs(args, "count")
+			if err != nil {
+				return nil, err
+			}
+
+			return constructor(orderedArgs["count"], rest...), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var _ adapters.SerializableNode = &ThresholdNode{}
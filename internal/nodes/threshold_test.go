@@ -0,0 +1,138 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Threshold(t *testing.T) {
+	scope := gon.NewScope()
+
+	checks := func(values ...bool) []adapters.Node {
+		children := make([]adapters.Node, 0, len(values))
+		for _, value := range values {
+			children = append(children, nodes.Literal(value))
+		}
+
+		return children
+	}
+
+	t.Run("should have at least one child", func(t *testing.T) {
+		_, err := scope.Compute(nodes.AtLeast(nodes.Literal(1)))
+		require.ErrorIs(t, err, adapters.ErrMustHaveArguments)
+	})
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.AtMost(nil, nodes.Literal(true)))
+		require.ErrorIs(t, err, adapters.ErrAllNodesMustBeSet)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Exactly(nodes.Literal(1), nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should count true children", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			node     adapters.Node
+			expected bool
+		}{
+			{"atLeast reached", nodes.AtLeast(nodes.Literal(2), checks(true, false, true, false)...), true},
+			{"atLeast missed", nodes.AtLeast(nodes.Literal(3), checks(true, false, true, false)...), false},
+			{"atLeast zero", nodes.AtLeast(nodes.Literal(0), checks(false)...), true},
+			{"atMost within", nodes.AtMost(nodes.Literal(1), checks(false, true, false)...), true},
+			{"atMost exceeded", nodes.AtMost(nodes.Literal(1), checks(true, true, false)...), false},
+			{"exactly matched", nodes.Exactly(nodes.Literal(int64(2)), checks(true, false, true)...), true},
+			{"exactly exceeded", nodes.Exactly(nodes.Literal(1), checks(true, false, true)...), false},
+			{"exactly missed", nodes.Exactly(nodes.Literal(3), checks(true, false, true)...), false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := scope.Compute(tc.node)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, got)
+			})
+		}
+	})
+
+	t.Run("should stop once the result is known", func(t *testing.T) {
+		failing := nodes.Literal(assert.AnError)
+
+		testCases := []adapters.Node{
+			nodes.AtLeast(nodes.Literal(1), nodes.Literal(true), failing),
+			nodes.AtLeast(nodes.Literal(2), nodes.Literal(false), nodes.Literal(false), failing),
+			nodes.AtMost(nodes.Literal(0), nodes.Literal(true), failing),
+			nodes.Exactly(nodes.Literal(0), nodes.Literal(true), failing),
+		}
+
+		for _, node := range testCases {
+			_, err := scope.Compute(node)
+			require.NoError(t, err, node.Scalar())
+		}
+	})
+
+	t.Run("should return non-boolean values like or", func(t *testing.T) {
+		got, err := scope.Compute(nodes.AtLeast(nodes.Literal(2), nodes.Literal(true), nodes.Literal("value")))
+		require.NoError(t, err)
+		require.Equal(t, "value", got)
+	})
+
+	t.Run("should error on invalid counts", func(t *testing.T) {
+		for _, count := range []any{"two", 1.5, -1, int64(-1), decimal.MustParse("1.5")} {
+			_, err := scope.Compute(nodes.AtLeast(nodes.Literal(count), nodes.Literal(true)))
+			require.ErrorAs(t, err, &adapters.NodeError{}, count)
+			require.ErrorAs(t, err, &adapters.UnexpectedTypeError{}, count)
+		}
+
+		got, err := scope.Compute(nodes.AtLeast(nodes.Literal(1.0), nodes.Literal(true)))
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+
+	t.Run("should be decoded from text", func(t *testing.T) {
+		node, err := encoding.Decode([]byte(`atLeast(2, true, false, exactly(1, true, false))`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		got, err := scope.Compute(node)
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+	})
+}
+
+func Test_Threshold_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.AtLeast(nodes.Literal(int64(2)), nodes.Reference("kyc.address"), nodes.Reference("kyc.phone"))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
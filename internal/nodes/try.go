@@ -0,0 +1,120 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+)
+
+type TryNode struct {
+	expression adapters.Node
+	fallback   adapters.Node
+	kinds      []string
+}
+
+// Try defines an error handling node.
+// It evaluates the fallback when the expression fails with an error of one of the given kinds, or with any error if no kind is given.
+// The kinds are adapters.ErrorCodes, like definitionNotFound, typeMismatch, callFailure and custom, for errors of the error node.
+// An error matches a kind if any error in its chain has the code, see adapters.HasCode.
+// Example: try(user.discount, 0, "definitionNotFound").
+func Try(expression, fallback adapters.Node, kinds ...string) adapters.Node {
+	if expression == nil || fallback == nil {
+		return adapters.NodeError{
+			NodeScalar: "try",
+			Cause:      adapters.ErrAllNodesMustBeSet,
+		}
+	}
+
+	for _, kind := range kinds {
+		if !adapters.ErrorCode(kind).IsValid() {
+			return adapters.NodeError{
+				NodeScalar: "try",
+				Cause:      fmt.Errorf("unknown error kind '%s', expected one of %v", kind, adapters.ErrorCodes),
+			}
+		}
+	}
+
+	return &TryNode{
+		expression: expression,
+		fallback:   fallback,
+		kinds:      kinds,
+	}
+}
+
+func (node *TryNode) Scalar() string {
+	return "try"
+}
+
+func (node *TryNode) Shape() []adapters.KeyNode {
+	kv := make([]adapters.KeyNode, 0, len(node.kinds)+2)
+	kv = append(kv,
+		adapters.KeyNode{Key: "expression", Node: node.expression},
+		adapters.KeyNode{Key: "fallback", Node: node.fallback},
+	)
+
+	for _, kind := range node.kinds {
+		kv = append(kv, adapters.KeyNode{Node: Literal(kind)})
+	}
+
+	return kv
+}
+
+func (node *TryNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *TryNode) Eval(scope adapters.Scope) adapters.Value {
+	value, err := scope.Compute(node.expression)
+	if err == nil {
+		return Literal(value)
+	}
+
+	if !node.catches(err) {
+		return adapters.NewNodeError(node, err)
+	}
+
+	return evalBranch(node, scope, node.fallback)
+}
+
+func (node *TryNode) catches(err error) bool {
+	if len(node.kinds) == 0 {
+		return true
+	}
+
+	for _, kind := range node.kinds {
+		if adapters.HasCode(err, adapters.ErrorCode(kind)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (node *TryNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		orderedArgs, rest, err := gonutils.SortArgs(args, "expression", "fallback")
+		if err != nil {
+			return nil, err
+		}
+
+		kinds := make([]string, 0, len(rest))
+		for i := range rest {
+			valued, ok := rest[i].(adapters.Valued)
+			if !ok {
+				return nil, fmt.Errorf("expected string literal")
+			}
+
+			kind, ok := valued.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", valued.Value())
+			}
+
+			kinds = append(kinds, kind)
+		}
+
+		return Try(orderedArgs["expression"], orderedArgs["fallback"], kinds...), nil
+	})
+}
+
+var _ adapters.SerializableNode = &TryNode{}
@@ -0,0 +1,126 @@
+package nodes_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Try(t *testing.T) {
+	scope, err := gon.NewScope().WithValues(gon.Values{
+		"fail": nodes.Literal(func() error { return assert.AnError }),
+	})
+	require.NoError(t, err)
+
+	t.Run("should error on unset nodes", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Try(nil, nodes.Literal(1)))
+		require.ErrorIs(t, err, adapters.ErrAllNodesMustBeSet)
+
+		_, err = scope.Compute(nodes.Try(nodes.Literal(1), nil))
+		require.ErrorIs(t, err, adapters.ErrAllNodesMustBeSet)
+	})
+
+	t.Run("should error on unknown kinds", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Try(nodes.Literal(1), nodes.Literal(2), "unknown"))
+		require.ErrorContains(t, err, "unknown error kind 'unknown'")
+	})
+
+	t.Run("should return the expression value", func(t *testing.T) {
+		value, err := scope.Compute(nodes.Try(nodes.Literal(1), nodes.Literal(assert.AnError)))
+		require.NoError(t, err)
+		require.Equal(t, 1, value)
+	})
+
+	t.Run("should catch any error without kinds", func(t *testing.T) {
+		value, err := scope.Compute(nodes.Try(nodes.Literal(assert.AnError), nodes.Literal(2)))
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+	})
+
+	testCases := []struct {
+		name       string
+		expression adapters.Node
+		kind       string
+	}{
+		{name: "definition not found", expression: nodes.Reference("missing"), kind: "definitionNotFound"},
+		{name: "type mismatch", expression: nodes.Not(nodes.Literal(1)), kind: "typeMismatch"},
+		{name: "incompatible pair", expression: nodes.Equal(nodes.Literal(1), nodes.Literal("1")), kind: "typeMismatch"},
+		{name: "call failure", expression: nodes.Call("fail"), kind: "callFailure"},
+		{name: "custom error", expression: nodes.Error(nodes.Literal("failed")), kind: "custom"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("should catch "+tc.name, func(t *testing.T) {
+			value, err := scope.Compute(nodes.Try(tc.expression, nodes.Literal(2), tc.kind))
+			require.NoError(t, err)
+			require.Equal(t, 2, value)
+		})
+
+		t.Run("should not catch "+tc.name+" of other kinds", func(t *testing.T) {
+			for kind := range map[string]struct{}{"definitionNotFound": {}, "typeMismatch": {}, "callFailure": {}, "custom": {}} {
+				if kind == tc.kind {
+					continue
+				}
+
+				_, err := scope.Compute(nodes.Try(tc.expression, nodes.Literal(2), kind))
+				require.ErrorAs(t, err, &adapters.NodeError{}, kind)
+			}
+		})
+	}
+
+	t.Run("should propagate fallback error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Try(nodes.Reference("missing"), nodes.Error(nodes.Literal("no fallback"))))
+		require.ErrorAs(t, err, &adapters.CustomError{})
+	})
+
+	t.Run("should not evaluate fallback on success", func(t *testing.T) {
+		value, err := scope.Compute(nodes.Try(nodes.Literal(true), nodes.Error(nodes.Literal("unreachable"))))
+		require.NoError(t, err)
+		require.Equal(t, true, value)
+	})
+
+	t.Run("should keep the cause of uncaught errors", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Try(nodes.Call("fail"), nodes.Literal(2), "custom"))
+		require.True(t, errors.Is(err, assert.AnError))
+	})
+}
+
+func Test_Try_Encoding(t *testing.T) {
+	t.Run("should decode with kinds", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Try(nodes.Reference("value"), nodes.Literal(1), "definitionNotFound", "typeMismatch")
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+
+	t.Run("should reject kinds that are not string literals", func(t *testing.T) {
+		_, err := encoding.Decode([]byte(`try(value, 1, kind)`), encoding.DefaultExpressionCodex)
+		require.Error(t, err)
+	})
+}
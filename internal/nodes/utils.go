@@ -2,8 +2,14 @@ package nodes
 
 import (
 	"cmp"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
 	"time"
 
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
 	"golang.org/x/exp/constraints"
 )
 
@@ -15,7 +21,53 @@ func safeGet[T any](slice []T, index int) T {
 	return slice[index]
 }
 
+// isNull reports whether the value is nil, including nil pointers, maps, slices, channels, functions and interfaces.
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	switch valueOf := reflect.ValueOf(value); valueOf.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return valueOf.IsNil()
+	default:
+		return false
+	}
+}
+
+// hasNull reports whether any of the values is null.
+func hasNull(values ...any) bool {
+	for _, value := range values {
+		if isNull(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalAny compares the values for equality.
+// Null values are only equal to other null values, and never incompatible with other types.
+func equalAny(firstValue, secondValue any) (bool, bool) {
+	if isNull(firstValue) || isNull(secondValue) {
+		return isNull(firstValue) && isNull(secondValue), true
+	}
+
+	comparison, ok := cmpAny(firstValue, secondValue)
+
+	return comparison == 0, ok
+}
+
 func cmpAny(firstValue, secondValue any) (int, bool) {
+	if hasDecimal(firstValue, secondValue) {
+		decimals, ok := castDecimals(firstValue, secondValue)
+		if !ok {
+			return 0, false
+		}
+
+		return decimals[0].Cmp(decimals[1]), true
+	}
+
 	switch c1 := firstValue.(type) {
 	case int:
 		c2, ok := secondValue.(int)
@@ -125,6 +177,15 @@ func sumAny(values ...any) (any, bool) {
 		return 0, false
 	}
 
+	if hasDecimal(values...) {
+		decimals, ok := castDecimals(values...)
+		if !ok {
+			return 0, false
+		}
+
+		return sumDecimals(decimals...), true
+	}
+
 	switch values[0].(type) {
 	case int:
 		values, ok := castAll[int](values...)
@@ -214,6 +275,17 @@ func avgAny(values ...any) (any, bool) {
 		return 0, false
 	}
 
+	if hasDecimal(values...) {
+		decimals, ok := castDecimals(values...)
+		if !ok {
+			return 0, false
+		}
+
+		average, err := sumDecimals(decimals...).Div(decimal.FromInt(int64(len(decimals))))
+
+		return average, err == nil
+	}
+
 	switch values[0].(type) {
 	case int:
 		values, ok := castAll[int](values...)
@@ -334,3 +406,533 @@ func castAll[T any](values ...any) ([]T, bool) {
 
 	return output, true
 }
+
+// arithmeticAny applies the operator from left to right.
+// All values should have the same numeric type.
+func arithmeticAny(operator byte, values ...any) (any, error) {
+	if len(values) == 0 {
+		return nil, adapters.ErrMustHaveArguments
+	}
+
+	if hasNull(values...) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	if hasDecimal(values...) {
+		return arithmeticDecimal(operator, values...)
+	}
+
+	switch values[0].(type) {
+	case int:
+		return arithmetic[int](operator, values...)
+	case int8:
+		return arithmetic[int8](operator, values...)
+	case int16:
+		return arithmetic[int16](operator, values...)
+	case int32:
+		return arithmetic[int32](operator, values...)
+	case int64:
+		return arithmetic[int64](operator, values...)
+	case uint:
+		return arithmetic[uint](operator, values...)
+	case uint8:
+		return arithmetic[uint8](operator, values...)
+	case uint16:
+		return arithmetic[uint16](operator, values...)
+	case uint32:
+		return arithmetic[uint32](operator, values...)
+	case uint64:
+		return arithmetic[uint64](operator, values...)
+	case uintptr:
+		return arithmetic[uintptr](operator, values...)
+	case float32:
+		return arithmetic[float32](operator, values...)
+	case float64:
+		return arithmetic[float64](operator, values...)
+	default:
+		return nil, adapters.UnexpectedTypeError{Expected: "number", Got: values[0]}
+	}
+}
+
+func arithmetic[T constraints.Float | constraints.Integer](operator byte, values ...any) (any, error) {
+	numbers, ok := castAll[T](values...)
+	if !ok {
+		return nil, adapters.ErrAllNodesMustMatch
+	}
+
+	result := numbers[0]
+	isInteger := T(1)/2 == 0
+
+	for _, number := range numbers[1:] {
+		var overflows bool
+
+		// Integer results that wrap around have the wrong sign, or don't reverse the operation.
+		switch operator {
+		case '-':
+			difference := result - number
+			overflows = number > 0 && difference >= result || number < 0 && difference <= result
+			result = difference
+		case '*':
+			product := result * number
+			overflows = result != 0 && number != 0 && (product/result != number || (result < 0) != (number < 0) != (product < 0))
+			result = product
+		case '/':
+			if number == 0 {
+				return nil, adapters.ErrDivisionByZero
+			}
+
+			quotient := result / number
+			overflows = result < 0 && number < 0 && quotient < 0
+			result = quotient
+		}
+
+		if isInteger && overflows {
+			return nil, adapters.ErrNumberOverflow
+		}
+	}
+
+	return result, nil
+}
+
+// hasDecimal reports whether any of the values is a decimal, so the values should be operated as decimals.
+func hasDecimal(values ...any) bool {
+	for _, value := range values {
+		if _, ok := value.(decimal.Decimal); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// castDecimals converts the values to decimals.
+// Integers are converted exactly, while floats are rejected, since they would bring back their rounding errors.
+func castDecimals(values ...any) ([]decimal.Decimal, bool) {
+	output := make([]decimal.Decimal, 0, len(values))
+
+	for _, value := range values {
+		converted, ok := ConvertValue(value, reflect.TypeFor[decimal.Decimal]())
+		if !ok {
+			return nil, false
+		}
+
+		output = append(output, converted.Interface().(decimal.Decimal))
+	}
+
+	return output, true
+}
+
+func sumDecimals(values ...decimal.Decimal) decimal.Decimal {
+	var total decimal.Decimal
+
+	for i := range values {
+		total = total.Add(values[i])
+	}
+
+	return total
+}
+
+func arithmeticDecimal(operator byte, values ...any) (any, error) {
+	decimals, ok := castDecimals(values...)
+	if !ok {
+		return nil, adapters.ErrAllNodesMustMatch
+	}
+
+	result := decimals[0]
+
+	for _, number := range decimals[1:] {
+		switch operator {
+		case '-':
+			result = result.Sub(number)
+		case '*':
+			result = result.Mul(number)
+		case '/':
+			quotient, err := result.Div(number)
+			if err != nil {
+				return nil, err
+			}
+
+			result = quotient
+		}
+	}
+
+	return result, nil
+}
+
+// extremumAny returns the smallest value when sign is -1, or the largest when sign is 1.
+// Values are compared like cmpAny, so integers and decimals can be mixed.
+func extremumAny(sign int, values ...any) (any, error) {
+	if len(values) == 0 {
+		return nil, adapters.ErrMustHaveArguments
+	}
+
+	if hasNull(values...) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	result := values[0]
+
+	for _, value := range values[1:] {
+		comparison, ok := cmpAny(value, result)
+		if !ok {
+			return nil, adapters.IncompatiblePairError{First: result, Second: value}
+		}
+
+		if comparison == sign {
+			result = value
+		}
+	}
+
+	return result, nil
+}
+
+// absAny returns the absolute value of the number, keeping its type.
+func absAny(value any) (any, error) {
+	if isNull(value) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	if d, ok := value.(decimal.Decimal); ok {
+		return d.Abs(), nil
+	}
+
+	valueOf := reflect.ValueOf(value)
+
+	switch {
+	case valueOf.CanInt():
+		if valueOf.Int() >= 0 {
+			return value, nil
+		}
+
+		// The negation of the minimum integer overflows back to itself.
+		negated := reflect.New(valueOf.Type()).Elem()
+		negated.SetInt(-valueOf.Int())
+
+		if negated.Int() < 0 {
+			return nil, adapters.ErrNumberOverflow
+		}
+
+		return negated.Interface(), nil
+	case valueOf.CanUint():
+		return value, nil
+	case valueOf.CanFloat():
+		return reflect.ValueOf(math.Abs(valueOf.Float())).Convert(valueOf.Type()).Interface(), nil
+	default:
+		return nil, adapters.UnexpectedTypeError{Expected: "number", Got: value}
+	}
+}
+
+// powAny raises the base to the exponent, keeping the type of the base.
+// Floats accept any numeric exponent, while integers and decimals require an integer exponent, which can't be negative for integers.
+func powAny(base, exponent any) (any, error) {
+	if hasNull(base, exponent) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	baseOf := reflect.ValueOf(base)
+
+	if baseOf.CanFloat() {
+		floatExponent, ok := ConvertValue(exponent, reflect.TypeFor[float64]())
+		if !ok {
+			return nil, adapters.UnexpectedTypeError{Expected: "numeric exponent", Got: exponent}
+		}
+
+		return reflect.ValueOf(math.Pow(baseOf.Float(), floatExponent.Float())).Convert(baseOf.Type()).Interface(), nil
+	}
+
+	intExponent, ok := ConvertValue(exponent, reflect.TypeFor[int64]())
+	if !ok {
+		return nil, adapters.UnexpectedTypeError{Expected: "integer exponent", Got: exponent}
+	}
+
+	if d, ok := base.(decimal.Decimal); ok {
+		return d.Pow(intExponent.Int())
+	}
+
+	if !baseOf.CanInt() && !baseOf.CanUint() {
+		return nil, adapters.UnexpectedTypeError{Expected: "number", Got: base}
+	}
+
+	if intExponent.Int() < 0 {
+		return nil, adapters.UnexpectedTypeError{Expected: "non-negative exponent for integers", Got: exponent}
+	}
+
+	bigBase := new(big.Int)
+	if baseOf.CanInt() {
+		bigBase.SetInt64(baseOf.Int())
+	} else {
+		bigBase.SetUint64(baseOf.Uint())
+	}
+
+	// Powers are computed exactly, and must fit in the type of the base.
+	// Bases other than 0, 1 and -1 overflow any integer type when the exponent reaches its bit size.
+	if bigBase.CmpAbs(big.NewInt(1)) > 0 && intExponent.Int() >= int64(baseOf.Type().Bits()) {
+		return nil, adapters.ErrNumberOverflow
+	}
+
+	power := bigBase.Exp(bigBase, big.NewInt(intExponent.Int()), nil)
+
+	result := reflect.New(baseOf.Type()).Elem()
+
+	switch {
+	case baseOf.CanInt() && power.IsInt64() && !result.OverflowInt(power.Int64()):
+		result.SetInt(power.Int64())
+	case baseOf.CanUint() && power.IsUint64() && !result.OverflowUint(power.Uint64()):
+		result.SetUint(power.Uint64())
+	default:
+		return nil, adapters.ErrNumberOverflow
+	}
+
+	return result.Interface(), nil
+}
+
+// sqrtAny returns the square root of the number.
+// Decimals keep their type, while integers and floats return a float64.
+func sqrtAny(value any) (any, error) {
+	if isNull(value) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	if d, ok := value.(decimal.Decimal); ok {
+		if d.Sign() < 0 {
+			return nil, adapters.ErrNegativeRoot
+		}
+
+		return d.Sqrt()
+	}
+
+	number, ok := ConvertValue(value, reflect.TypeFor[float64]())
+	if !ok {
+		return nil, adapters.UnexpectedTypeError{Expected: "number", Got: value}
+	}
+
+	if number.Float() < 0 {
+		return nil, adapters.ErrNegativeRoot
+	}
+
+	return math.Sqrt(number.Float()), nil
+}
+
+// clampAny limits the value to the range from lower to upper, which should be comparable with it.
+func clampAny(value, lower, upper any) (any, error) {
+	if hasNull(value, lower, upper) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	rangeComparison, ok := cmpAny(lower, upper)
+	if !ok {
+		return nil, adapters.IncompatiblePairError{First: lower, Second: upper}
+	}
+
+	if rangeComparison > 0 {
+		return nil, adapters.ErrInvalidRange
+	}
+
+	lowerComparison, ok := cmpAny(value, lower)
+	if !ok {
+		return nil, adapters.IncompatiblePairError{First: value, Second: lower}
+	}
+
+	upperComparison, ok := cmpAny(value, upper)
+	if !ok {
+		return nil, adapters.IncompatiblePairError{First: value, Second: upper}
+	}
+
+	switch {
+	case lowerComparison < 0:
+		return lower, nil
+	case upperComparison > 0:
+		return upper, nil
+	default:
+		return value, nil
+	}
+}
+
+// elementsOf returns the elements of slices and arrays, or the values of maps sorted by their keys.
+// Null collections have no elements.
+func elementsOf(collection any) ([]any, error) {
+	valueOf := reflect.ValueOf(collection)
+
+	for valueOf.Kind() == reflect.Pointer || valueOf.Kind() == reflect.Interface {
+		valueOf = valueOf.Elem()
+	}
+
+	if !valueOf.IsValid() {
+		return nil, nil
+	}
+
+	switch valueOf.Kind() {
+	case reflect.Slice, reflect.Array:
+		elements := make([]any, 0, valueOf.Len())
+		for i := range valueOf.Len() {
+			elements = append(elements, valueOf.Index(i).Interface())
+		}
+
+		return elements, nil
+	case reflect.Map:
+		keys := sortedKeys(valueOf)
+
+		elements := make([]any, 0, len(keys))
+		for _, key := range keys {
+			elements = append(elements, valueOf.MapIndex(key).Interface())
+		}
+
+		return elements, nil
+	default:
+		return nil, adapters.UnexpectedTypeError{Expected: "collection", Got: collection}
+	}
+}
+
+// keysOf returns the sorted keys of maps, and false for other values.
+func keysOf(collection any) ([]any, bool) {
+	valueOf := reflect.ValueOf(collection)
+
+	for valueOf.Kind() == reflect.Pointer || valueOf.Kind() == reflect.Interface {
+		valueOf = valueOf.Elem()
+	}
+
+	if valueOf.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	keys := sortedKeys(valueOf)
+
+	elements := make([]any, 0, len(keys))
+	for _, key := range keys {
+		elements = append(elements, key.Interface())
+	}
+
+	return elements, true
+}
+
+func sortedKeys(valueOf reflect.Value) []reflect.Value {
+	keys := valueOf.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		comparison, _ := cmpAny(a.Interface(), b.Interface())
+		return comparison
+	})
+
+	return keys
+}
+
+// floatsOf converts numbers of the same type to float64.
+func floatsOf(values ...any) ([]float64, error) {
+	floats := make([]float64, 0, len(values))
+
+	for _, value := range values {
+		if reflect.TypeOf(value) != reflect.TypeOf(values[0]) {
+			return nil, adapters.ErrAllNodesMustMatch
+		}
+
+		if !isNumber(reflect.ValueOf(value).Kind()) {
+			return nil, adapters.UnexpectedTypeError{Expected: "number", Got: value}
+		}
+
+		converted, _ := ConvertValue(value, reflect.TypeFor[float64]())
+		floats = append(floats, converted.Float())
+	}
+
+	return floats, nil
+}
+
+// percentileAny returns the percentile of the values, from 0 to 100, interpolating linearly between the closest ranks.
+// Decimals keep their type, and can be mixed with integers, while integers and floats return a float64.
+func percentileAny(percentile float64, values ...any) (any, error) {
+	if !(percentile >= 0 && percentile <= 100) {
+		return nil, adapters.ErrInvalidPercentile
+	}
+
+	if len(values) == 0 {
+		return nil, adapters.ErrEmptyCollection
+	}
+
+	if hasNull(values...) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	rank := percentile / 100 * float64(len(values)-1)
+	lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+	fraction := rank - float64(lower)
+
+	if hasDecimal(values...) {
+		decimals, ok := castDecimals(values...)
+		if !ok {
+			return nil, adapters.ErrAllNodesMustMatch
+		}
+
+		slices.SortFunc(decimals, decimal.Decimal.Cmp)
+
+		if lower == upper {
+			return decimals[lower], nil
+		}
+
+		weight, err := decimal.FromFloat(fraction)
+		if err != nil {
+			return nil, err
+		}
+
+		return decimals[lower].Add(decimals[upper].Sub(decimals[lower]).Mul(weight)), nil
+	}
+
+	floats, err := floatsOf(values...)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(floats)
+
+	return floats[lower] + (floats[upper]-floats[lower])*fraction, nil
+}
+
+// stddevAny returns the population standard deviation of the values.
+// Decimals keep their type, and can be mixed with integers, while integers and floats return a float64.
+func stddevAny(values ...any) (any, error) {
+	if len(values) == 0 {
+		return nil, adapters.ErrEmptyCollection
+	}
+
+	if hasNull(values...) {
+		return nil, adapters.ErrNullOperand
+	}
+
+	if hasDecimal(values...) {
+		decimals, ok := castDecimals(values...)
+		if !ok {
+			return nil, adapters.ErrAllNodesMustMatch
+		}
+
+		count := decimal.FromInt(int64(len(decimals)))
+
+		mean, err := sumDecimals(decimals...).Div(count)
+		if err != nil {
+			return nil, err
+		}
+
+		var squares decimal.Decimal
+		for _, value := range decimals {
+			deviation := value.Sub(mean)
+			squares = squares.Add(deviation.Mul(deviation))
+		}
+
+		variance, err := squares.Div(count)
+		if err != nil {
+			return nil, err
+		}
+
+		return variance.Sqrt()
+	}
+
+	floats, err := floatsOf(values...)
+	if err != nil {
+		return nil, err
+	}
+
+	mean := sum(floats...) / float64(len(floats))
+
+	var squares float64
+	for _, value := range floats {
+		squares += (value - mean) * (value - mean)
+	}
+
+	return math.Sqrt(squares / float64(len(floats))), nil
+}
@@ -0,0 +1,81 @@
+package nodes
+
+import (
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/gonutils"
+	"github.com/sonalys/gon/internal/sliceutils"
+)
+
+type XorNode struct {
+	nodes []adapters.Node
+}
+
+// Xor defines a xor node, there must be at least one input.
+// It returns true when an odd number of inputs are true, so every input is evaluated.
+// Like or, it returns the value of the first error or non-boolean expression.
+func Xor(nodes ...adapters.Node) adapters.Node {
+	if len(nodes) == 0 {
+		return adapters.NodeError{
+			NodeScalar: "xor",
+			Cause:      adapters.ErrMustHaveArguments,
+		}
+	}
+
+	for i := range nodes {
+		if nodes[i] == nil {
+			return adapters.NodeError{
+				NodeScalar: "xor",
+				Cause:      adapters.ErrAllNodesMustBeSet,
+			}
+		}
+	}
+
+	return &XorNode{
+		nodes: nodes,
+	}
+}
+
+func (node *XorNode) Scalar() string {
+	return "xor"
+}
+
+func (node *XorNode) Shape() []adapters.KeyNode {
+	return sliceutils.Map(node.nodes, func(from adapters.Node) adapters.KeyNode { return adapters.KeyNode{Node: from} })
+}
+
+func (node *XorNode) Type() adapters.NodeType {
+	return adapters.NodeTypeExpression
+}
+
+func (node *XorNode) Eval(scope adapters.Scope) adapters.Value {
+	result := false
+
+	for _, expr := range node.nodes {
+		value, err := scope.Compute(expr)
+		if err != nil {
+			return adapters.NewNodeError(node, err)
+		}
+
+		switch value := value.(type) {
+		case bool:
+			result = result != value
+		default:
+			return Literal(value)
+		}
+	}
+
+	return Literal(result)
+}
+
+func (node *XorNode) Register(codex adapters.Codex) error {
+	return codex.Register(node.Scalar(), func(args []adapters.KeyNode) (adapters.Node, error) {
+		_, argsSlice, err := gonutils.SortArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return Xor(argsSlice...), nil
+	})
+}
+
+var _ adapters.SerializableNode = &XorNode{}
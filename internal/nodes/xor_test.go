@@ -0,0 +1,84 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Xor(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should have at least one child", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Xor())
+		require.Error(t, err)
+	})
+
+	t.Run("should not have unset children", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Xor(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("should propagate error", func(t *testing.T) {
+		_, err := scope.Compute(nodes.Xor(nodes.Literal(true), nodes.Literal(assert.AnError)))
+		require.ErrorAs(t, err, &adapters.NodeError{})
+	})
+
+	t.Run("should be true for an odd number of true children", func(t *testing.T) {
+		testCases := []struct {
+			children []adapters.Node
+			expected bool
+		}{
+			{[]adapters.Node{nodes.Literal(true), nodes.Literal(false)}, true},
+			{[]adapters.Node{nodes.Literal(true), nodes.Literal(true)}, false},
+			{[]adapters.Node{nodes.Literal(false), nodes.Literal(false)}, false},
+			{[]adapters.Node{nodes.Literal(true), nodes.Literal(true), nodes.Literal(true)}, true},
+		}
+
+		for _, tc := range testCases {
+			got, err := scope.Compute(nodes.Xor(tc.children...))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+		}
+	})
+
+	t.Run("should return non-boolean values like or", func(t *testing.T) {
+		got, err := scope.Compute(nodes.Xor(nodes.Literal(true), nodes.Literal("value")))
+		require.NoError(t, err)
+		require.Equal(t, "value", got)
+	})
+}
+
+func Test_Xor_Encoding(t *testing.T) {
+	t.Run("should decode with children", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			node := nodes.Xor(nodes.Literal(true), nodes.Literal(false))
+
+			shaped, ok := node.(adapters.Shaped)
+			require.True(t, ok)
+
+			kns := shaped.Shape()
+
+			registerer, ok := node.(adapters.AutoRegisterer)
+			require.True(t, ok)
+
+			codex := make(encoding.Codex)
+
+			err := registerer.Register(&codex)
+			require.NoError(t, err)
+
+			named, ok := node.(adapters.Named)
+			require.True(t, ok)
+			assert.NotEmpty(t, named.Scalar())
+
+			got, err := codex[named.Scalar()](kns)
+			require.NoError(t, err)
+			require.Equal(t, node, got)
+		})
+	})
+}
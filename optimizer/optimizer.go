@@ -0,0 +1,345 @@
+// Package optimizer simplifies expression trees, without changing their results.
+package optimizer
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/internal/nodes"
+)
+
+type optimizer struct {
+	codex encoding.Codex
+	scope adapters.Scope
+}
+
+// pureScalars are the expressions that depend only on their arguments.
+// They can be folded into a literal when all their arguments are literals.
+var pureScalars = map[string]struct{}{
+	"abs":          {},
+	"and":          {},
+	"atLeast":      {},
+	"atMost":       {},
+	"avg":          {},
+	"avgOf":        {},
+	"ceil":         {},
+	"clamp":        {},
+	"cond":         {},
+	"countOf":      {},
+	"difference":   {},
+	"distinct":     {},
+	"div":          {},
+	"equal":        {},
+	"exactly":      {},
+	"first":        {},
+	"flatten":      {},
+	"floor":        {},
+	"groupBy":      {},
+	"gt":           {},
+	"gte":          {},
+	"hasPrefix":    {},
+	"hasSuffix":    {},
+	"if":           {},
+	"implies":      {},
+	"in":           {},
+	"intersect":    {},
+	"isEmpty":      {},
+	"isError":      {},
+	"isNull":       {},
+	"isSubset":     {},
+	"last":         {},
+	"lt":           {},
+	"lte":          {},
+	"max":          {},
+	"maxOf":        {},
+	"medianOf":     {},
+	"min":          {},
+	"minOf":        {},
+	"mul":          {},
+	"not":          {},
+	"or":           {},
+	"overlaps":     {},
+	"percentileOf": {},
+	"pow":          {},
+	"round":        {},
+	"slice":        {},
+	"sort":         {},
+	"sortDesc":     {},
+	"sqrt":         {},
+	"stddevOf":     {},
+	"sub":          {},
+	"sum":          {},
+	"sumOf":        {},
+	"switch":       {},
+	"try":          {},
+	"union":        {},
+	"xor":          {},
+}
+
+// booleanScalars are the expressions that always evaluate to a boolean or an error.
+var booleanScalars = map[string]struct{}{
+	"equal":     {},
+	"exists":    {},
+	"gt":        {},
+	"gte":       {},
+	"hasPrefix": {},
+	"hasSuffix": {},
+	"in":        {},
+	"isEmpty":   {},
+	"isError":   {},
+	"isNull":    {},
+	"isSubset":  {},
+	"lt":        {},
+	"lte":       {},
+	"not":       {},
+	"overlaps":  {},
+}
+
+// Optimize returns a smaller tree, equivalent to root. It will:
+//   - fold expressions with only literal arguments into a literal. Example: sum(1, 2) becomes 3.
+//   - remove double negations of boolean expressions. Example: not(not(gt(a, b))) becomes gt(a, b).
+//   - remove dead branches. Example: if(true, a, b) becomes a.
+//   - flatten nested or, and remove unreachable or false arguments. Example: or(false, or(a, b)) becomes or(a, b).
+//
+// Expressions that depend on the scope, like call, exists, coalesce or custom nodes, are never folded.
+// Expressions that evaluate to errors are kept, so the error happens during evaluation.
+// The codex is used for rebuilding the expressions with optimized arguments.
+func Optimize(root adapters.Node, codex encoding.Codex) (adapters.Node, error) {
+	o := &optimizer{
+		codex: codex,
+		scope: gon.NewScope(),
+	}
+
+	node, _, err := o.optimize(root)
+	if err != nil {
+		return nil, fmt.Errorf("optimizing expression: %w", err)
+	}
+
+	return node, nil
+}
+
+// optimize returns the optimized node, and whether it changed.
+func (o *optimizer) optimize(node adapters.Node) (adapters.Node, bool, error) {
+	expression, ok := node.(adapters.SerializableNode)
+	if !ok || expression.Type() != adapters.NodeTypeExpression || isConstant(node) {
+		return node, false, nil
+	}
+
+	scalar := expression.Scalar()
+	shape := expression.Shape()
+
+	args := make([]adapters.KeyNode, 0, len(shape))
+	changed := false
+
+	for _, arg := range shape {
+		optimized, argChanged, err := o.optimize(arg.Node)
+		if err != nil {
+			return nil, false, err
+		}
+
+		caseNode, caseChanged, err := o.optimize(arg.Case)
+		if err != nil {
+			return nil, false, err
+		}
+
+		changed = changed || argChanged || caseChanged
+		args = append(args, adapters.KeyNode{Key: arg.Key, Case: caseNode, Node: optimized})
+	}
+
+	switch scalar {
+	case "not":
+		if inner, ok := doubleNegation(args); ok {
+			return inner, true, nil
+		}
+	case "if":
+		if branch, ok := deadBranch(args); ok {
+			return branch, true, nil
+		}
+	case "or":
+		orArgs, orChanged := flattenOr(args)
+		switch {
+		case len(orArgs) == 0:
+			return nodes.Literal(false), true, nil
+		case len(orArgs) == 1:
+			return orArgs[0].Node, true, nil
+		}
+
+		args = orArgs
+		changed = changed || orChanged
+	}
+
+	if changed {
+		constructor, ok := o.codex[scalar]
+		if !ok {
+			return nil, false, fmt.Errorf("codex for '%s' not found", scalar)
+		}
+
+		rebuilt, err := constructor(args)
+		if err != nil {
+			return nil, false, fmt.Errorf("rebuilding '%s': %w", scalar, err)
+		}
+
+		node = rebuilt
+	}
+
+	if folded, ok := o.fold(node, scalar, args); ok {
+		return folded, true, nil
+	}
+
+	return node, changed, nil
+}
+
+// fold evaluates pure expressions with only literal arguments.
+func (o *optimizer) fold(node adapters.Node, scalar string, args []adapters.KeyNode) (adapters.Node, bool) {
+	if _, ok := pureScalars[scalar]; !ok {
+		return nil, false
+	}
+
+	for i := range args {
+		if !isConstant(args[i].Node) || args[i].Case != nil && !isConstant(args[i].Case) {
+			return nil, false
+		}
+	}
+
+	value, err := o.scope.Compute(node)
+	if err != nil || !isEncodable(value) {
+		return nil, false
+	}
+
+	return nodes.Literal(value), true
+}
+
+// doubleNegation returns x from not(not(x)), if x is a boolean expression.
+// Non-boolean expressions are kept, because not(not(x)) fails for them.
+func doubleNegation(args []adapters.KeyNode) (adapters.Node, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+
+	inner, ok := args[0].Node.(adapters.SerializableNode)
+	if !ok || !isExpression(inner, "not") {
+		return nil, false
+	}
+
+	innerArgs := inner.Shape()
+	if len(innerArgs) != 1 || !isBoolean(innerArgs[0].Node) {
+		return nil, false
+	}
+
+	return innerArgs[0].Node, true
+}
+
+// deadBranch returns the only reachable branch of an if with a literal condition.
+func deadBranch(args []adapters.KeyNode) (adapters.Node, bool) {
+	if len(args) < 2 || !isConstant(args[0].Node) {
+		return nil, false
+	}
+
+	condition, ok := args[0].Node.(adapters.Valued).Value().(bool)
+	if !ok {
+		return nil, false
+	}
+
+	var branch adapters.Node
+	switch {
+	case condition:
+		branch = args[1].Node
+	case len(args) > 2:
+		branch = args[2].Node
+	default:
+		branch = nodes.Literal(false)
+	}
+
+	return branch, branch != nil
+}
+
+// flattenOr inlines nested or arguments, removes false literals,
+// and removes every argument after a true or non-boolean literal, since they are unreachable.
+func flattenOr(args []adapters.KeyNode) ([]adapters.KeyNode, bool) {
+	flattened := make([]adapters.KeyNode, 0, len(args))
+	changed := false
+
+	for _, arg := range args {
+		nested, ok := arg.Node.(adapters.SerializableNode)
+		if ok && isExpression(nested, "or") {
+			innerArgs, _ := flattenOr(nested.Shape())
+			flattened = append(flattened, innerArgs...)
+			changed = true
+			continue
+		}
+
+		flattened = append(flattened, arg)
+	}
+
+	reachable := make([]adapters.KeyNode, 0, len(flattened))
+
+	for _, arg := range flattened {
+		if !isConstant(arg.Node) {
+			reachable = append(reachable, arg)
+			continue
+		}
+
+		if value, ok := arg.Node.(adapters.Valued).Value().(bool); ok && !value {
+			changed = true
+			continue
+		}
+
+		reachable = append(reachable, arg)
+		break
+	}
+
+	return reachable, changed || len(reachable) != len(flattened)
+}
+
+// isConstant reports whether the node is a literal, that evaluates to itself.
+// Lazy literals and errors are not considered constant.
+func isConstant(node adapters.Node) bool {
+	literal, ok := node.(*nodes.LiteralNode)
+	if !ok {
+		return false
+	}
+
+	switch value := literal.Value().(type) {
+	case error:
+		return false
+	default:
+		return value == nil || reflect.TypeOf(value).Kind() != reflect.Func
+	}
+}
+
+func isBoolean(node adapters.Node) bool {
+	if isConstant(node) {
+		_, ok := node.(adapters.Valued).Value().(bool)
+		return ok
+	}
+
+	expression, ok := node.(adapters.SerializableNode)
+	if !ok || expression.Type() != adapters.NodeTypeExpression {
+		return false
+	}
+
+	_, ok = booleanScalars[expression.Scalar()]
+	return ok
+}
+
+// isExpression reports whether the node is an expression with the given scalar.
+// References are not expressions, even if their names match the scalar.
+func isExpression(node adapters.SerializableNode, scalar string) bool {
+	return node.Type() == adapters.NodeTypeExpression && node.Scalar() == scalar
+}
+
+// isEncodable reports whether the value can be encoded as a literal, including types with a literal codec.
+func isEncodable(value any) bool {
+	switch value.(type) {
+	case bool, string,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return true
+	default:
+		return nodes.HasLiteralCodec(value)
+	}
+}
@@ -0,0 +1,191 @@
+package optimizer_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/sonalys/gon/optimizer"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, node adapters.Node) string {
+	t.Helper()
+
+	buf := bytes.NewBuffer(nil)
+	err := encoding.HumanEncode(buf, node, encoding.Compact(), encoding.Unnamed())
+	require.NoError(t, err)
+
+	return buf.String()
+}
+
+func Test_Optimize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "should fold literal expressions", input: `sum(1, 2)`, expected: `3`},
+		{name: "should fold nested literal expressions", input: `if(gt(sum(1, 2), 2), "big", "small")`, expected: `"big"`},
+		{name: "should fold literal arguments", input: `equal(x, sum(1, 2))`, expected: `equal(x,3)`},
+		{name: "should remove double negation", input: `not(not(gt(x, 1)))`, expected: `gt(x,1)`},
+		{name: "should keep double negation of unknown types", input: `not(not(x))`, expected: `not(not(x))`},
+		{name: "should remove false from or", input: `or(false, x)`, expected: `x`},
+		{name: "should flatten nested or", input: `or(a, or(b, or(c, d)))`, expected: `or(a,b,c,d)`},
+		{name: "should remove unreachable or arguments", input: `or(a, true, b)`, expected: `or(a,true)`},
+		{name: "should remove arguments after non-boolean literal", input: `or(a, 5, b)`, expected: `or(a,5)`},
+		{name: "should replace or without arguments", input: `or(false, false)`, expected: `false`},
+		{name: "should remove else branch", input: `if(true, a, b)`, expected: `a`},
+		{name: "should remove then branch", input: `if(false, a, b)`, expected: `b`},
+		{name: "should remove if without else", input: `if(false, a)`, expected: `false`},
+		{name: "should optimize children of impure nodes", input: `call("f", sum(1, 2))`, expected: `call("f",3)`},
+		{name: "should not fold exists", input: `exists("x")`, expected: `exists("x")`},
+		{name: "should not fold errors", input: `sum(1, "a")`, expected: `sum(1,"a")`},
+		{name: "should not fold non-boolean conditions", input: `if(1, a, b)`, expected: `if(1,a,b)`},
+		{name: "should fold literal switch", input: `switch("gold", "gold": 0.2, default: 0.5)`, expected: `0.2`},
+		{name: "should fold literal cases", input: `switch(tier, sum(1, 2): "a")`, expected: `switch(tier,3: "a")`},
+		{name: "should fold literal cond", input: `cond(gt(1, 2): "a", default: "b")`, expected: `"b"`},
+		{name: "should fold try of literals", input: `try(1, 2)`, expected: `1`},
+		{name: "should not fold try of errors", input: `try(sum(1, "a"), 0)`, expected: `try(sum(1,"a"),0)`},
+		{name: "should keep inclusive comparisons", input: `gte(x, sum(1, 1))`, expected: `gte(x,2)`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node, err := encoding.Decode([]byte(tc.input), encoding.DefaultExpressionCodex)
+			require.NoError(t, err)
+
+			optimized, err := optimizer.Optimize(node, encoding.DefaultExpressionCodex)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, encode(t, optimized))
+		})
+	}
+
+	t.Run("should error on missing codex", func(t *testing.T) {
+		node := gon.Equal(gon.Reference("x"), gon.Sum(gon.Literal(1), gon.Literal(2)))
+
+		_, err := optimizer.Optimize(node, encoding.Codex{})
+		require.Error(t, err)
+	})
+
+	t.Run("should keep references named as scalars", func(t *testing.T) {
+		node := gon.Or(gon.Reference("or"), gon.Reference("x"))
+
+		optimized, err := optimizer.Optimize(node, encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+		require.Equal(t, node, optimized)
+	})
+}
+
+// exprGenerator generates random well-typed and ill-typed expressions over a fixed set of definitions.
+type exprGenerator struct {
+	rand *rand.Rand
+}
+
+var (
+	boolReferences = []string{"flag1", "flag2", "flag3"}
+	intReferences  = []string{"num1", "num2"}
+)
+
+func (g *exprGenerator) boolExpr(depth int) adapters.Node {
+	if depth <= 0 {
+		if g.rand.IntN(2) == 0 {
+			return gon.Literal(g.rand.IntN(2) == 0)
+		}
+		return gon.Reference(boolReferences[g.rand.IntN(len(boolReferences))])
+	}
+
+	switch g.rand.IntN(8) {
+	case 0:
+		return gon.Not(g.boolExpr(depth - 1))
+	case 1:
+		args := make([]adapters.Node, 1+g.rand.IntN(3))
+		for i := range args {
+			args[i] = g.boolExpr(depth - 1)
+		}
+		return gon.Or(args...)
+	case 2:
+		if g.rand.IntN(2) == 0 {
+			return gon.If(g.boolExpr(depth-1), g.boolExpr(depth-1))
+		}
+		return gon.If(g.boolExpr(depth-1), g.boolExpr(depth-1), g.boolExpr(depth-1))
+	case 3:
+		return gon.Equal(g.intExpr(depth-1), g.intExpr(depth-1))
+	case 4:
+		return gon.Greater(g.intExpr(depth-1), g.intExpr(depth-1))
+	case 5:
+		return gon.SmallerOrEqual(g.intExpr(depth-1), g.intExpr(depth-1))
+	case 6:
+		// Ill-typed expression, to ensure errors are preserved.
+		return gon.Not(g.intExpr(depth - 1))
+	default:
+		return g.boolExpr(0)
+	}
+}
+
+func (g *exprGenerator) intExpr(depth int) adapters.Node {
+	if depth <= 0 {
+		if g.rand.IntN(2) == 0 {
+			return gon.Literal(g.rand.Int64N(5))
+		}
+		return gon.Reference(intReferences[g.rand.IntN(len(intReferences))])
+	}
+
+	switch g.rand.IntN(3) {
+	case 0:
+		args := make([]adapters.Node, 1+g.rand.IntN(3))
+		for i := range args {
+			args[i] = g.intExpr(depth - 1)
+		}
+		return gon.Sum(args...)
+	case 1:
+		return gon.If(g.boolExpr(depth-1), g.intExpr(depth-1), g.intExpr(depth-1))
+	default:
+		return g.intExpr(0)
+	}
+}
+
+func Test_Optimize_Equivalence(t *testing.T) {
+	g := &exprGenerator{
+		rand: rand.New(rand.NewPCG(1, 2)),
+	}
+
+	for i := range 500 {
+		expr := g.boolExpr(1 + i%5)
+
+		optimized, err := optimizer.Optimize(expr, encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		// The optimized expression should encode and decode back to an equivalent expression.
+		decoded, err := encoding.Decode([]byte(encode(t, optimized)), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		for range 10 {
+			values := gon.Values{}
+			for _, key := range boolReferences {
+				values[key] = gon.Literal(g.rand.IntN(2) == 0)
+			}
+			for _, key := range intReferences {
+				values[key] = gon.Literal(g.rand.Int64N(5))
+			}
+
+			scope, err := gon.NewScope().WithValues(values)
+			require.NoError(t, err)
+
+			msg := fmt.Sprintf("original: %s\noptimized: %s", encode(t, expr), encode(t, optimized))
+
+			expected, expectedErr := scope.Compute(expr)
+
+			for _, candidate := range []adapters.Node{optimized, decoded} {
+				got, gotErr := scope.Compute(candidate)
+				require.Equal(t, expectedErr != nil, gotErr != nil, msg)
+				require.Equal(t, expected, got, msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package gon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/ast"
+)
+
+// ProviderFunc adapts a function to a definition provider.
+// It receives the full definition key, including children attributes. Example: flags.new_checkout.
+type ProviderFunc func(ctx context.Context, key string) (adapters.Value, bool, error)
+
+func (f ProviderFunc) Provide(ctx context.Context, key string) (adapters.Value, bool, error) {
+	return f(ctx, key)
+}
+
+// WithProviders returns a new scope with the given providers layered on top of the receiver.
+// Definitions are resolved from the newest layer to the oldest, and inside a layer, providers
+// are consulted in the given order, until one of them provides the key.
+// The scope context is given to the providers, so lookups are cancelled with it.
+func (s *scope) WithProviders(providers ...adapters.DefinitionProvider) *scope {
+	return &scope{
+		Context:   s.Context,
+		providers: slices.Clone(providers),
+		parent:    s,
+	}
+}
+
+// Prefetch gathers all definitions the root node depends on,
+// and gives them to every provider implementing adapters.DefinitionPrefetcher.
+// It allows providers backed by external lookups to fetch all keys in a single round-trip.
+func (s *scope) Prefetch(root adapters.Node) error {
+	astNode, err := ast.Parse(root)
+	if err != nil {
+		return fmt.Errorf("parsing expression: %w", err)
+	}
+
+	keys := ast.DependencyNames(ast.Dependencies(astNode))
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for layer := s; layer != nil; layer = layer.parent {
+		for _, provider := range layer.providers {
+			prefetcher, ok := provider.(adapters.DefinitionPrefetcher)
+			if !ok {
+				continue
+			}
+
+			if err := prefetcher.Prefetch(s, keys); err != nil {
+				return fmt.Errorf("prefetching definitions: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package gon_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchProvider struct {
+	mu      sync.Mutex
+	source  map[string]any
+	fetched map[string]any
+	batches [][]string
+}
+
+func (p *batchProvider) Provide(ctx context.Context, key string) (adapters.Value, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	value, ok := p.fetched[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return gon.Literal(value), true, nil
+}
+
+func (p *batchProvider) Prefetch(ctx context.Context, keys []string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.batches = append(p.batches, keys)
+
+	if p.fetched == nil {
+		p.fetched = make(map[string]any)
+	}
+
+	for _, key := range keys {
+		if value, ok := p.source[key]; ok {
+			p.fetched[key] = value
+		}
+	}
+
+	return nil
+}
+
+func mapProvider(source map[string]any) gon.ProviderFunc {
+	return func(ctx context.Context, key string) (adapters.Value, bool, error) {
+		value, ok := source[key]
+		if !ok {
+			return nil, false, nil
+		}
+		return gon.Literal(value), true, nil
+	}
+}
+
+func Test_Scope_WithProviders(t *testing.T) {
+	t.Run("should resolve definitions from provider", func(t *testing.T) {
+		scope := gon.NewScope().WithProviders(mapProvider(map[string]any{
+			"flags.checkout": true,
+		}))
+
+		value, err := scope.Compute(gon.Reference("flags.checkout"))
+		require.NoError(t, err)
+		require.Equal(t, true, value)
+	})
+
+	t.Run("should consult providers in order", func(t *testing.T) {
+		scope := gon.NewScope().WithProviders(
+			mapProvider(map[string]any{"first": 1}),
+			mapProvider(map[string]any{"first": 2, "second": 2}),
+		)
+
+		value, err := scope.Compute(gon.Reference("first"))
+		require.NoError(t, err)
+		require.Equal(t, 1, value)
+
+		value, err = scope.Compute(gon.Reference("second"))
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+	})
+
+	t.Run("newer layers should take precedence", func(t *testing.T) {
+		scope, err := gon.NewScope().
+			WithProviders(mapProvider(map[string]any{"key": "provider"})).
+			WithValues(gon.Values{"key": gon.Literal("values")})
+		require.NoError(t, err)
+
+		value, err := scope.Compute(gon.Reference("key"))
+		require.NoError(t, err)
+		require.Equal(t, "values", value)
+
+		scope = scope.WithProviders(mapProvider(map[string]any{"key": "override"}))
+
+		value, err = scope.Compute(gon.Reference("key"))
+		require.NoError(t, err)
+		require.Equal(t, "override", value)
+	})
+
+	t.Run("should receive the scope context", func(t *testing.T) {
+		type ctxKey struct{}
+
+		ctx := context.WithValue(t.Context(), ctxKey{}, "tenant")
+
+		scope := gon.NewScope().
+			WithProviders(gon.ProviderFunc(func(ctx context.Context, key string) (adapters.Value, bool, error) {
+				return gon.Literal(ctx.Value(ctxKey{})), true, nil
+			})).
+			WithContext(ctx)
+
+		value, err := scope.Compute(gon.Reference("anything"))
+		require.NoError(t, err)
+		require.Equal(t, "tenant", value)
+	})
+
+	t.Run("should propagate provider errors", func(t *testing.T) {
+		scope := gon.NewScope().WithProviders(gon.ProviderFunc(func(ctx context.Context, key string) (adapters.Value, bool, error) {
+			return nil, false, assert.AnError
+		}))
+
+		_, err := scope.Compute(gon.Reference("key"))
+		require.ErrorIs(t, err, assert.AnError)
+
+		var target adapters.DefinitionProviderError
+		require.ErrorAs(t, err, &target)
+		require.Equal(t, "key", target.Key())
+	})
+
+	t.Run("should error when no provider has the definition", func(t *testing.T) {
+		scope := gon.NewScope().WithProviders(mapProvider(nil))
+
+		_, err := scope.Compute(gon.Reference("key"))
+
+		var target adapters.DefinitionNotFoundError
+		require.ErrorAs(t, err, &target)
+	})
+}
+
+func Test_Scope_Prefetch(t *testing.T) {
+	provider := &batchProvider{
+		source: map[string]any{
+			"user.age":     int64(20),
+			"config.min":   int64(18),
+			"notify":       func(age int64) string { return "ok" },
+			"unrelatedKey": true,
+		},
+	}
+
+	scope := gon.NewScope().WithProviders(provider)
+
+	rule, err := encoding.Decode([]byte(`if(gte(user.age, config.min), call("notify", user.age))`), encoding.DefaultExpressionCodex)
+	require.NoError(t, err)
+
+	err = scope.Prefetch(rule)
+	require.NoError(t, err)
+
+	require.Len(t, provider.batches, 1)
+	require.Equal(t, []string{"config.min", "notify", "user.age"}, provider.batches[0])
+
+	value, err := scope.Compute(rule)
+	require.NoError(t, err)
+	require.Equal(t, "ok", value)
+
+	t.Run("should propagate prefetch errors", func(t *testing.T) {
+		scope := gon.NewScope().WithProviders(failingPrefetcher{})
+
+		err := scope.Prefetch(gon.Reference("key"))
+		require.ErrorIs(t, err, assert.AnError)
+		require.True(t, strings.HasPrefix(err.Error(), "prefetching definitions"))
+	})
+}
+
+type failingPrefetcher struct{}
+
+func (failingPrefetcher) Provide(ctx context.Context, key string) (adapters.Value, bool, error) {
+	return nil, false, nil
+}
+
+func (failingPrefetcher) Prefetch(ctx context.Context, keys []string) error {
+	return assert.AnError
+}
@@ -2,16 +2,23 @@ package gon
 
 import (
 	"context"
+	"strings"
 
 	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/internal/nodes"
 )
 
 type (
+	// scope is an immutable layer of definitions on top of an optional parent scope.
+	// A layer either holds static definitions, a chain of definition providers or functions.
+	// Lookups walk from the newest layer to the oldest, so newer definitions shadow older ones.
 	scope struct {
-		store adapters.DefinitionReadWriter
 		context.Context
 
-		parentScope adapters.Scope
+		store     *definitionStore
+		providers []adapters.DefinitionProvider
+		functions Functions
+		parent    *scope
 	}
 )
 
@@ -19,42 +26,73 @@ type (
 // A Scope can be used to evaluate expressions under specific conditions.
 // It can also define context for evaluation and define data for the expressions.
 // It starts with a background context by default.
+//
+// Scopes are immutable: WithContext and WithValues return new scopes, leaving the receiver untouched.
+// A scope is safe for concurrent use, so it can be built once and shared between goroutines,
+// with each goroutine deriving its own scope with a request context or request values.
 func NewScope() *scope {
 	return &scope{
 		Context: context.Background(),
-		store:   newDefinitionResolver(),
+		store:   newDefinitionStore(),
 	}
 }
 
+// WithContext returns a copy of the scope using ctx for evaluation.
+// The definitions are shared with the receiver.
 func (s *scope) WithContext(ctx context.Context) *scope {
-	s.Context = ctx
-	return s
+	return &scope{
+		Context:   ctx,
+		store:     s.store,
+		providers: s.providers,
+		functions: s.functions,
+		parent:    s.parent,
+	}
 }
 
+// WithValues returns a new scope with the given definitions layered on top of the receiver.
+// Definitions from source shadow the receiver definitions with the same top-level key.
 func (s *scope) WithValues(source Values) (*scope, error) {
+	store := newDefinitionStore()
+
 	for key, value := range source {
-		if !keyValidationRegex.MatchString(key) {
-			return nil, adapters.InvalidDefinitionKey{
-				DefinitionKey: key,
-			}
-		}
-		if err := s.store.Define(key, value); err != nil {
+		if err := store.Define(key, value); err != nil {
 			return nil, err
 		}
 	}
-	return s, nil
+
+	return &scope{
+		Context: s.Context,
+		store:   store,
+		parent:  s,
+	}, nil
 }
 
 func (s *scope) Definition(key string) (adapters.Value, bool) {
-	value, ok := s.store.Definition(key)
-	if !ok {
-		if s.parentScope != nil {
-			return s.parentScope.Definition(key)
+	topKey, _, _ := strings.Cut(key, ".")
+
+	for layer := s; layer != nil; layer = layer.parent {
+		if layer.store != nil && layer.store.defines(topKey) {
+			return layer.store.Definition(key)
+		}
+
+		for _, provider := range layer.providers {
+			value, ok, err := provider.Provide(s, key)
+			if err != nil {
+				return nodes.Literal(adapters.DefinitionProviderError{
+					DefinitionKey: key,
+					Cause:         err,
+				}), false
+			}
+
+			if ok {
+				return value, true
+			}
 		}
-		return value, false
 	}
 
-	return value, true
+	return nodes.Literal(adapters.DefinitionNotFoundError{
+		DefinitionKey: topKey,
+	}), false
 }
 
 // Compute will evaluate the final value for the root node.
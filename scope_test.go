@@ -0,0 +1,165 @@
+package gon_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Scope_WithContext(t *testing.T) {
+	t.Run("should not modify the receiver", func(t *testing.T) {
+		base := gon.NewScope()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		derived := base.WithContext(ctx)
+
+		require.Error(t, derived.Err())
+		require.NoError(t, base.Err())
+	})
+
+	t.Run("should share definitions with the receiver", func(t *testing.T) {
+		base, err := gon.NewScope().WithValues(gon.Values{
+			"var": gon.Literal(1),
+		})
+		require.NoError(t, err)
+
+		derived := base.WithContext(t.Context())
+
+		value, ok := derived.Definition("var")
+		require.True(t, ok)
+		require.Equal(t, 1, value.Value())
+	})
+}
+
+func Test_Scope_WithValues(t *testing.T) {
+	t.Run("should not modify the receiver", func(t *testing.T) {
+		base := gon.NewScope()
+
+		derived, err := base.WithValues(gon.Values{
+			"var": gon.Literal(1),
+		})
+		require.NoError(t, err)
+
+		_, ok := derived.Definition("var")
+		require.True(t, ok)
+
+		_, ok = base.Definition("var")
+		require.False(t, ok)
+	})
+
+	t.Run("should inherit parent definitions", func(t *testing.T) {
+		base, err := gon.NewScope().WithValues(gon.Values{
+			"parent": gon.Literal(1),
+		})
+		require.NoError(t, err)
+
+		derived, err := base.WithValues(gon.Values{
+			"child": gon.Literal(2),
+		})
+		require.NoError(t, err)
+
+		value, ok := derived.Definition("parent")
+		require.True(t, ok)
+		require.Equal(t, 1, value.Value())
+	})
+
+	t.Run("should shadow parent definitions", func(t *testing.T) {
+		type person struct {
+			Name string `gon:"name"`
+			Age  int    `gon:"age"`
+		}
+
+		type pet struct {
+			Name string `gon:"name"`
+		}
+
+		base, err := gon.NewScope().WithValues(gon.Values{
+			"owner": gon.Literal(person{Name: "parent", Age: 30}),
+		})
+		require.NoError(t, err)
+
+		derived, err := base.WithValues(gon.Values{
+			"owner": gon.Literal(pet{Name: "child"}),
+		})
+		require.NoError(t, err)
+
+		value, ok := derived.Definition("owner.name")
+		require.True(t, ok)
+		require.Equal(t, "child", value.Value())
+
+		_, ok = derived.Definition("owner.age")
+		require.False(t, ok, "nested keys should not leak from shadowed definitions")
+	})
+
+	t.Run("should error on invalid key", func(t *testing.T) {
+		_, err := gon.NewScope().WithValues(gon.Values{
+			"1invalid": gon.Literal(1),
+		})
+		require.ErrorAs(t, err, &adapters.InvalidDefinitionKey{})
+	})
+}
+
+func Test_Scope_Concurrency(t *testing.T) {
+	type workerKey struct{}
+
+	type User struct {
+		ID  int64 `gon:"id"`
+		Age int64 `gon:"age"`
+	}
+
+	base, err := gon.NewScope().WithValues(gon.Values{
+		"min_age": gon.Literal(int64(17)),
+	})
+	require.NoError(t, err)
+
+	rule, err := encoding.Decode([]byte(`if(gt(user.age, min_age), user.id, -1)`), encoding.DefaultExpressionCodex)
+	require.NoError(t, err)
+
+	const workers = 64
+
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Go(func() {
+			ctx := context.WithValue(t.Context(), workerKey{}, i)
+
+			user := &User{ID: int64(i), Age: int64(i % 30)}
+
+			scope, err := base.
+				WithContext(ctx).
+				WithValues(gon.Values{
+					"user": gon.Literal(user),
+				})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for range 100 {
+				got, err := scope.Compute(rule)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				expected := int64(-1)
+				if user.Age > 17 {
+					expected = user.ID
+				}
+
+				assert.Equal(t, expected, got, fmt.Sprintf("worker %d", i))
+			}
+		})
+	}
+
+	wg.Wait()
+
+	_, ok := base.Definition("user")
+	require.False(t, ok, "derived scopes should not leak into the base scope")
+}
@@ -0,0 +1,81 @@
+package gon
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/decimal"
+	"github.com/sonalys/gon/internal/nodes"
+	"golang.org/x/exp/constraints"
+)
+
+// Eval evaluates the node, returning its value converted to T.
+// If the value is of type error, it will be returned as error instead.
+//
+// Values assignable to T are returned as they are.
+// Numbers convert to any float type, and to integer types when they are representable without losing precision.
+// Example: 2.0 converts to int, while 2.5 and -1 to uint fail.
+// Values that cannot be converted return an adapters.TypeConversionError.
+func Eval[T any](scope adapters.Scope, node adapters.Node) (T, error) {
+	var zero T
+
+	value := node.Eval(scope).Value()
+	if err, ok := value.(error); ok {
+		return zero, err
+	}
+
+	target := reflect.TypeFor[T]()
+
+	converted, ok := nodes.ConvertValue(value, target)
+	if !ok {
+		return zero, adapters.TypeConversionError{
+			Value:  value,
+			Target: target,
+		}
+	}
+
+	// Null converts to the zero value of interfaces, which can't be asserted from nil.
+	result, _ := converted.Interface().(T)
+
+	return result, nil
+}
+
+// Int returns an int64 literal, the type of integers in decoded rules.
+// Unsigned values above math.MaxInt64 return a literal of adapters.ErrNumberOverflow.
+func Int[N constraints.Integer](value N) *nodes.LiteralNode {
+	if value > 0 && uint64(value) > math.MaxInt64 {
+		return nodes.Literal(adapters.NodeError{
+			NodeScalar: "literal",
+			Cause:      adapters.ErrNumberOverflow,
+		})
+	}
+
+	return nodes.Literal(int64(value))
+}
+
+// Float returns a float64 literal, the type of decimal numbers in decoded rules.
+func Float[N constraints.Integer | constraints.Float](value N) *nodes.LiteralNode {
+	return nodes.Literal(float64(value))
+}
+
+// String returns a string literal.
+func String(value string) *nodes.LiteralNode {
+	return nodes.Literal(value)
+}
+
+// Bool returns a bool literal.
+func Bool(value bool) *nodes.LiteralNode {
+	return nodes.Literal(value)
+}
+
+// Decimal returns a decimal literal, for exact arithmetic.
+func Decimal(value decimal.Decimal) *nodes.LiteralNode {
+	return nodes.Literal(value)
+}
+
+// Time returns a time literal.
+func Time(value time.Time) *nodes.LiteralNode {
+	return nodes.Literal(value)
+}
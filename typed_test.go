@@ -0,0 +1,133 @@
+package gon_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/adapters"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Eval(t *testing.T) {
+	scope := gon.NewScope()
+
+	t.Run("should return values of the same type", func(t *testing.T) {
+		got, err := gon.Eval[string](scope, gon.String("value"))
+		require.NoError(t, err)
+		require.Equal(t, "value", got)
+	})
+
+	t.Run("should convert integers", func(t *testing.T) {
+		got, err := gon.Eval[int](scope, gon.Sum(gon.Int(1), gon.Int(2)))
+		require.NoError(t, err)
+		require.Equal(t, 3, got)
+
+		small, err := gon.Eval[uint8](scope, gon.Int(255))
+		require.NoError(t, err)
+		require.Equal(t, uint8(255), small)
+	})
+
+	t.Run("should convert integral floats to integers", func(t *testing.T) {
+		got, err := gon.Eval[int64](scope, gon.Float(2))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), got)
+	})
+
+	t.Run("should convert numbers to floats", func(t *testing.T) {
+		got, err := gon.Eval[float32](scope, gon.Int(3))
+		require.NoError(t, err)
+		require.Equal(t, float32(3), got)
+	})
+
+	t.Run("should return values as interfaces", func(t *testing.T) {
+		got, err := gon.Eval[any](scope, gon.Int(3))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), got)
+	})
+
+	t.Run("should return nil for nillable types", func(t *testing.T) {
+		got, err := gon.Eval[[]string](scope, gon.Literal(nil))
+		require.NoError(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("should return nil for interfaces", func(t *testing.T) {
+		got, err := gon.Eval[any](scope, gon.Literal(nil))
+		require.NoError(t, err)
+		require.Nil(t, got)
+
+		_, err = gon.Eval[error](scope, gon.Literal(nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail on lossy conversions", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			eval func() error
+		}{
+			{name: "fraction to integer", eval: func() error { _, err := gon.Eval[int](scope, gon.Float(2.5)); return err }},
+			{name: "negative to unsigned", eval: func() error { _, err := gon.Eval[uint](scope, gon.Int(-1)); return err }},
+			{name: "negative float to unsigned", eval: func() error { _, err := gon.Eval[uint](scope, gon.Float(-1)); return err }},
+			{name: "out of range", eval: func() error { _, err := gon.Eval[int8](scope, gon.Int(128)); return err }},
+			{name: "unsigned out of range", eval: func() error { _, err := gon.Eval[int64](scope, gon.Literal(uint64(math.MaxUint64))); return err }},
+			{name: "infinity to integer", eval: func() error { _, err := gon.Eval[int](scope, gon.Float(math.Inf(1))); return err }},
+			{name: "number to string", eval: func() error { _, err := gon.Eval[string](scope, gon.Int(65)); return err }},
+			{name: "string to bool", eval: func() error { _, err := gon.Eval[bool](scope, gon.String("true")); return err }},
+			{name: "nil to integer", eval: func() error { _, err := gon.Eval[int](scope, gon.Literal(nil)); return err }},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var conversionErr adapters.TypeConversionError
+				require.ErrorAs(t, tc.eval(), &conversionErr)
+			})
+		}
+	})
+
+	t.Run("should return evaluation errors", func(t *testing.T) {
+		_, err := gon.Eval[int](scope, gon.Reference("missing"))
+
+		var notFound adapters.DefinitionNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+}
+
+func Test_TypedLiterals(t *testing.T) {
+	t.Run("should compare with decoded literals", func(t *testing.T) {
+		now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		scope, err := gon.NewScope().WithValues(gon.Values{
+			"count": gon.Int(int8(3)),
+			"ratio": gon.Float(float32(0.5)),
+			"name":  gon.String("alice"),
+			"admin": gon.Bool(true),
+			"since": gon.Time(now),
+		})
+		require.NoError(t, err)
+
+		rule, err := encoding.Decode([]byte(`if(equal(count, 3), if(equal(ratio, 0.5), if(equal(name, "alice"), if(admin, gt(since, time("2024-01-01T00:00:00Z"))))))`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		got, err := gon.Eval[bool](scope, rule)
+		require.NoError(t, err)
+		require.True(t, got)
+	})
+
+	t.Run("should error on integers above int64", func(t *testing.T) {
+		scope := gon.NewScope()
+
+		_, err := scope.Compute(gon.Int(uint64(math.MaxUint64)))
+		require.ErrorIs(t, err, adapters.ErrNumberOverflow)
+
+		got, err := scope.Compute(gon.Int(uint64(math.MaxInt64)))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MaxInt64), got)
+
+		got, err = scope.Compute(gon.Int(int8(-3)))
+		require.NoError(t, err)
+		require.Equal(t, int64(-3), got)
+	})
+}
@@ -0,0 +1,79 @@
+package gon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValuesFromJSON reads a JSON object, defining each top-level key with its value.
+// Integer numbers are decoded as int64, and the other numbers as float64, matching the types of decoded rule literals.
+// Nested objects are accessible by their keys, and arrays by their indexes. Example: orders.0.total.
+func ValuesFromJSON(r io.Reader) (Values, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("decoding json values: %w", err)
+	}
+
+	return documentValues(document), nil
+}
+
+// ValuesFromYAML reads a YAML mapping, defining each top-level key with its value.
+// Numbers and nested values follow the same rules as ValuesFromJSON, and timestamps are decoded as time.Time.
+func ValuesFromYAML(r io.Reader) (Values, error) {
+	var document map[string]any
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("decoding yaml values: %w", err)
+	}
+
+	return documentValues(document), nil
+}
+
+func documentValues(document map[string]any) Values {
+	values := make(Values, len(document))
+
+	for key, value := range document {
+		values[key] = Literal(normalizeDocumentValue(value))
+	}
+
+	return values
+}
+
+// normalizeDocumentValue converts numbers to int64 or float64, and map keys to strings.
+func normalizeDocumentValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	case uint64:
+		return float64(v)
+	case map[string]any:
+		for key := range v {
+			v[key] = normalizeDocumentValue(v[key])
+		}
+	case map[any]any:
+		normalized := make(map[string]any, len(v))
+		for key := range v {
+			normalized[fmt.Sprint(key)] = normalizeDocumentValue(v[key])
+		}
+
+		return normalized
+	case []any:
+		for i := range v {
+			v[i] = normalizeDocumentValue(v[i])
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,110 @@
+package gon_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sonalys/gon"
+	"github.com/sonalys/gon/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValuesFromJSON(t *testing.T) {
+	document := `{
+		"user": {"name": "alice", "age": 19, "score": 9.5},
+		"orders": [{"total": 10}, {"total": 25}],
+		"limit": 20
+	}`
+
+	t.Run("should decode numbers as int64 or float64", func(t *testing.T) {
+		values, err := gon.ValuesFromJSON(strings.NewReader(document))
+		require.NoError(t, err)
+
+		scope, err := gon.NewScope().WithValues(values)
+		require.NoError(t, err)
+
+		age, ok := scope.Definition("user.age")
+		require.True(t, ok)
+		require.Equal(t, int64(19), age.Value())
+
+		score, ok := scope.Definition("user.score")
+		require.True(t, ok)
+		require.Equal(t, 9.5, score.Value())
+	})
+
+	t.Run("should compare with decoded literals", func(t *testing.T) {
+		values, err := gon.ValuesFromJSON(strings.NewReader(document))
+		require.NoError(t, err)
+
+		scope, err := gon.NewScope().WithValues(values)
+		require.NoError(t, err)
+
+		rule, err := encoding.Decode([]byte(`if(equal(user.age, 19), gt(orders.1.total, limit))`), encoding.DefaultExpressionCodex)
+		require.NoError(t, err)
+
+		value, err := scope.Compute(rule)
+		require.NoError(t, err)
+		require.Equal(t, true, value)
+	})
+
+	t.Run("should not resolve out of range indexes", func(t *testing.T) {
+		values, err := gon.ValuesFromJSON(strings.NewReader(document))
+		require.NoError(t, err)
+
+		scope, err := gon.NewScope().WithValues(values)
+		require.NoError(t, err)
+
+		for _, key := range []string{"orders.2.total", "orders.-1.total", "orders.first"} {
+			_, ok := scope.Definition(key)
+			require.False(t, ok, key)
+		}
+	})
+
+	t.Run("should fail on documents that are not objects", func(t *testing.T) {
+		_, err := gon.ValuesFromJSON(strings.NewReader(`[1, 2]`))
+		require.Error(t, err)
+	})
+}
+
+func Test_ValuesFromYAML(t *testing.T) {
+	document := `
+user:
+  name: alice
+  age: 19
+  joined: 2024-01-02T03:04:05Z
+  tags: [admin, beta]
+ids:
+  1: first
+`
+
+	values, err := gon.ValuesFromYAML(strings.NewReader(document))
+	require.NoError(t, err)
+
+	scope, err := gon.NewScope().WithValues(values)
+	require.NoError(t, err)
+
+	t.Run("should decode integers as int64", func(t *testing.T) {
+		age, ok := scope.Definition("user.age")
+		require.True(t, ok)
+		require.Equal(t, int64(19), age.Value())
+	})
+
+	t.Run("should decode timestamps", func(t *testing.T) {
+		joined, ok := scope.Definition("user.joined")
+		require.True(t, ok)
+		require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), joined.Value())
+	})
+
+	t.Run("should index sequences", func(t *testing.T) {
+		tag, ok := scope.Definition("user.tags.1")
+		require.True(t, ok)
+		require.Equal(t, "beta", tag.Value())
+	})
+
+	t.Run("should convert keys to strings", func(t *testing.T) {
+		id, ok := scope.Definition("ids.1")
+		require.True(t, ok)
+		require.Equal(t, "first", id.Value())
+	})
+}